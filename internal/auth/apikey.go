@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// KeyPrefix starts the api keys, so they are told apart from the access tokens.
+const KeyPrefix = "stk_"
+
+// keyIDLen is the length of the key ids in bytes, the secrets are SecretLen bytes.
+const keyIDLen = 6
+
+// NewAPIKey returns a new key with its id, the key is KeyPrefix, the id and the secret joined by underscores.
+func NewAPIKey() (string, string) {
+	b := make([]byte, keyIDLen)
+	_, _ = rand.Read(b)
+
+	id := hex.EncodeToString(b)
+
+	return id, RotateAPIKey(id)
+}
+
+// RotateAPIKey returns a new key with the given id.
+func RotateAPIKey(id string) string {
+	secret := make([]byte, SecretLen)
+	_, _ = rand.Read(secret)
+
+	return KeyPrefix + id + "_" + base64.RawURLEncoding.EncodeToString(secret)
+}
+
+// APIKeyID returns the id of the key, ok is false when the value is not an api key.
+func APIKeyID(raw string) (string, bool) {
+	rest, ok := strings.CutPrefix(raw, KeyPrefix)
+	if !ok {
+		return "", false
+	}
+
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || id == "" || secret == "" {
+		return "", false
+	}
+
+	return id, true
+}
+
+// HashAPIKey hashes the key, the keys are random so a fast hash is enough.
+func HashAPIKey(raw string) []byte {
+	h := sha256.Sum256([]byte(raw))
+
+	return h[:]
+}
+
+// CheckAPIKey reports whether the key matches the hash.
+func CheckAPIKey(hash []byte, raw string) bool {
+	return subtle.ConstantTimeCompare(hash, HashAPIKey(raw)) == 1
+}
@@ -0,0 +1,76 @@
+// Package auth authenticates the requests with the bearer JWTs which are issued by the server itself.
+// Users log in with their password and receive a short-lived access token and a refresh token,
+// the refresh tokens are rotated on each use and any token can be revoked before it expires.
+// The authenticated principal is carried by the request context for both the REST and GraphQL APIs.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication is required")
+	ErrInvalidToken    = errors.New("token is invalid or expired")
+)
+
+// Principal is the authenticated user or api key of a request.
+type Principal struct {
+	// Username is the name of the api keys.
+	Username string `json:"username"`
+	// Role is empty for the api keys.
+	Role model.Role `json:"role,omitempty"`
+	// StudentID is the student of the users with the student role.
+	StudentID string `json:"student_id,omitempty"`
+	// KeyID and Scopes are the id of an api key and the only actions it can perform, Scopes is nil for the users.
+	KeyID  string   `json:"key_id,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Scoped reports whether the principal is an api key which is limited to its scopes.
+func (p Principal) Scoped() bool {
+	return p.Scopes != nil
+}
+
+type contextKey struct{}
+
+// WithPrincipal returns a context which carries the principal.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal of the context, ok is false for the unauthenticated contexts.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(Principal)
+
+	return p, ok
+}
+
+// HashPassword hashes the password with bcrypt.
+func HashPassword(password string) ([]byte, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("cannot hash the password %w", err)
+	}
+
+	return hash, nil
+}
+
+// dummy is compared with the passwords of the unknown users, so they take as long as the known ones.
+// nolint: gochecknoglobals
+var dummy, _ = bcrypt.GenerateFromPassword([]byte("students"), bcrypt.DefaultCost)
+
+// CheckPassword reports whether the password matches the hash, a nil hash never matches.
+func CheckPassword(hash []byte, password string) bool {
+	if hash == nil {
+		_ = bcrypt.CompareHashAndPassword(dummy, []byte(password))
+
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil
+}
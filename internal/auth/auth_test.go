@@ -0,0 +1,300 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/store/apikey"
+	"github.com/1995parham-teaching/students/internal/store/token"
+	"github.com/labstack/echo/v4"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{ //nolint:exhaustruct
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	// each connection has its own in-memory database.
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+
+	return db
+}
+
+func tokens(tenant string) auth.Tokens {
+	return auth.Tokens{
+		Secret:     []byte("secret"),
+		Tenant:     tenant,
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}
+}
+
+// nolint: gochecknoglobals
+var student = model.User{
+	Username:     "elahe",
+	PasswordHash: nil,
+	Role:         model.RoleStudent,
+	StudentID:    "12345678",
+	CreatedAt:    time.Time{},
+}
+
+func TestTokens_Parse(t *testing.T) {
+	t.Parallel()
+
+	pair, err := tokens("aut").Issue(student)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired := tokens("aut")
+	expired.AccessTTL = -time.Minute
+
+	old, err := expired.Issue(student)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := tokens("aut")
+	other.Secret = []byte("another secret")
+
+	cases := []struct {
+		name   string
+		tokens auth.Tokens
+		raw    string
+		typ    auth.Type
+		valid  bool
+	}{
+		{"access", tokens("aut"), pair.Access, auth.TypeAccess, true},
+		{"refresh", tokens("aut"), pair.Refresh, auth.TypeRefresh, true},
+		{"any", tokens("aut"), pair.Refresh, "", true},
+		{"refresh as access", tokens("aut"), pair.Refresh, auth.TypeAccess, false},
+		{"access as refresh", tokens("aut"), pair.Access, auth.TypeRefresh, false},
+		{"another tenant", tokens("sharif"), pair.Access, auth.TypeAccess, false},
+		{"another secret", other, pair.Access, auth.TypeAccess, false},
+		{"expired", tokens("aut"), old.Access, auth.TypeAccess, false},
+		{"tampered", tokens("aut"), pair.Access + "x", auth.TypeAccess, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims, err := tc.tokens.Parse(tc.raw, tc.typ)
+			if !tc.valid {
+				if !errors.Is(err, auth.ErrInvalidToken) {
+					t.Fatalf("expected an invalid token, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if p := claims.Principal(); p.Username != "elahe" || p.Role != model.RoleStudent || p.StudentID != "12345678" {
+				t.Errorf("unexpected principal %+v", p)
+			}
+		})
+	}
+}
+
+func newKey(t *testing.T, keys apikey.APIKey, expiresAt *time.Time) string {
+	t.Helper()
+
+	id, key := auth.NewAPIKey()
+
+	err := keys.Create(context.Background(), model.APIKey{
+		ID:         id,
+		Name:       "sync",
+		Hash:       auth.HashAPIKey(key),
+		Scopes:     []string{"students:read"},
+		ExpiresAt:  expiresAt,
+		LastUsedAt: nil,
+		RotatedAt:  nil,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+
+	a := auth.Authenticator{
+		Tokens:  tokens("aut"),
+		Revoked: token.NewSQL(db),
+		Keys:    apikey.NewSQL(db),
+	}
+
+	admin := student
+	admin.Username = "root"
+	admin.Role = model.RoleAdmin
+	admin.StudentID = ""
+
+	pair, err := a.Tokens.Issue(student)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := a.Tokens.Issue(admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked, err := a.Tokens.Issue(student)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := a.Tokens.Parse(revoked.Access, auth.TypeAccess)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = a.Revoked.Revoke(context.Background(), claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := newKey(t, a.Keys, nil)
+	past := time.Now().Add(-time.Minute)
+	expired := newKey(t, a.Keys, &past)
+	id, _ := auth.APIKeyID(key)
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(a.Middleware(func(c echo.Context) bool { return c.Path() == "/public" }))
+
+	app.GET("/me", func(c echo.Context) error {
+		p, _ := auth.FromContext(c.Request().Context())
+
+		return c.String(http.StatusOK, p.Username)
+	})
+	app.GET("/public", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	cases := []struct {
+		name          string
+		path          string
+		authorization string
+		status        int
+		username      string
+	}{
+		{"student", "/me", "Bearer " + pair.Access, 200, "elahe"},
+		{"admin", "/me", "bearer " + root.Access, 200, "root"},
+		{"missing", "/me", "", 401, ""},
+		{"basic", "/me", "Basic ZWxhaGU6cGFzcw==", 401, ""},
+		{"refresh", "/me", "Bearer " + pair.Refresh, 401, ""},
+		{"revoked", "/me", "Bearer " + revoked.Access, 401, ""},
+		{"public", "/public", "", 204, ""},
+		{"api key", "/me", "Bearer " + key, 200, "sync"},
+		{"expired api key", "/me", "Bearer " + expired, 401, ""},
+		{"another api key secret", "/me", "Bearer " + auth.RotateAPIKey(id), 401, ""},
+		{"unknown api key", "/me", "Bearer " + auth.RotateAPIKey("000000000000"), 401, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.authorization != "" {
+				r.Header.Set(echo.HeaderAuthorization, tc.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, w.Code, w.Body)
+			}
+
+			if tc.status == http.StatusUnauthorized && w.Header().Get(echo.HeaderWWWAuthenticate) != auth.Scheme {
+				t.Errorf("expected the bearer challenge, got %q", w.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+
+			if tc.username != "" && w.Body.String() != tc.username {
+				t.Errorf("expected %s, got %s", tc.username, w.Body.String())
+			}
+		})
+	}
+}
+
+// touches counts the usage records of the api keys.
+type touches struct {
+	apikey.APIKey
+
+	count int
+}
+
+func (t *touches) Touch(ctx context.Context, id string, at time.Time) error {
+	t.count++
+
+	return t.APIKey.Touch(ctx, id, at)
+}
+
+func TestAuthenticator_Touch(t *testing.T) {
+	t.Parallel()
+
+	keys := &touches{APIKey: apikey.NewSQL(setupTestDB(t)), count: 0}
+
+	a := auth.Authenticator{
+		Tokens:  tokens("aut"),
+		Revoked: nil,
+		Keys:    keys,
+	}
+
+	key := newKey(t, keys, nil)
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(a.Middleware(func(echo.Context) bool { return false }))
+
+	app.GET("/me", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for range 3 {
+		r := httptest.NewRequest(http.MethodGet, "/me", nil)
+		r.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body)
+		}
+	}
+
+	// the first request records the usage and the others are within its precision.
+	if keys.count != 1 {
+		t.Errorf("expected 1 usage record, got %d", keys.count)
+	}
+}
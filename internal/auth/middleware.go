@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/store/apikey"
+	"github.com/1995parham-teaching/students/internal/store/token"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Scheme is the authentication scheme of the Authorization header.
+const Scheme = "Bearer"
+
+// Authenticator authenticates the access tokens which are not revoked and the api keys which are not expired.
+type Authenticator struct {
+	Tokens  Tokens
+	Revoked token.Token
+	Keys    apikey.APIKey
+}
+
+// Authenticate returns the context of the given access token or api key.
+func (a Authenticator) Authenticate(ctx context.Context, raw string) (context.Context, error) {
+	if id, ok := APIKeyID(raw); ok {
+		return a.authenticateKey(ctx, id, raw)
+	}
+
+	claims, err := a.Tokens.Parse(raw, TypeAccess)
+	if err != nil {
+		return ctx, err
+	}
+
+	revoked, err := a.Revoked.Revoked(ctx, claims.ID)
+	if err != nil {
+		return ctx, fmt.Errorf("cannot check the token revocation %w", err)
+	}
+
+	if revoked {
+		return ctx, ErrInvalidToken
+	}
+
+	return WithPrincipal(ctx, claims.Principal()), nil
+}
+
+// authenticateKey returns the context of the api key.
+func (a Authenticator) authenticateKey(ctx context.Context, id string, raw string) (context.Context, error) {
+	k, err := a.Keys.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return ctx, ErrInvalidToken
+		}
+
+		return ctx, fmt.Errorf("cannot get the api key %w", err)
+	}
+
+	now := time.Now()
+
+	if !CheckAPIKey(k.Hash, raw) || k.Expired(now) {
+		return ctx, ErrInvalidToken
+	}
+
+	// the usage is only written once in each LastUsedPrecision, so the requests don't write on every call.
+	if k.LastUsedAt == nil || k.LastUsedAt.Before(now.Add(-apikey.LastUsedPrecision)) {
+		err = a.Keys.Touch(ctx, k.ID, now)
+		if err != nil {
+			return ctx, fmt.Errorf("cannot record the api key usage %w", err)
+		}
+	}
+
+	return WithPrincipal(ctx, Principal{
+		Username:  k.Name,
+		Role:      "",
+		StudentID: "",
+		KeyID:     k.ID,
+		Scopes:    k.Scopes,
+	}), nil
+}
+
+// Bearer returns the token of an Authorization header value.
+func Bearer(header string) (string, bool) {
+	scheme, raw, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, Scheme) || raw == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(raw), true
+}
+
+// unauthorized reports the authentication failures with the WWW-Authenticate challenge.
+func unauthorized(c echo.Context, code problem.Code, err error) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, Scheme)
+
+	p := problem.New(http.StatusUnauthorized, code, err.Error())
+	p.Err = err
+
+	return p
+}
+
+// Middleware rejects the requests which don't have a valid access token or api key, unless they are skipped.
+func (a Authenticator) Middleware(skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+
+			raw, ok := Bearer(c.Request().Header.Get(echo.HeaderAuthorization))
+			if !ok {
+				return unauthorized(c, problem.CodeUnauthorized, ErrUnauthenticated)
+			}
+
+			ctx, err := a.Authenticate(c.Request().Context(), raw)
+			if err != nil {
+				if errors.Is(err, ErrInvalidToken) {
+					return unauthorized(c, problem.CodeInvalidToken, ErrInvalidToken)
+				}
+
+				return problem.Internal(err)
+			}
+
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// Issuer is the issuer of the tokens.
+	Issuer = "students"
+	// DefaultAccessTTL is the lifetime of the access tokens.
+	DefaultAccessTTL = 15 * time.Minute
+	// DefaultRefreshTTL is the lifetime of the refresh tokens.
+	DefaultRefreshTTL = 7 * 24 * time.Hour
+	// SecretLen is the length of the generated secrets in bytes.
+	SecretLen = 32
+)
+
+// Type separates the access tokens from the refresh tokens, so a refresh token cannot be used as an access token.
+type Type string
+
+const (
+	TypeAccess  Type = "access"
+	TypeRefresh Type = "refresh"
+)
+
+// Claims are the claims of the tokens, the subject is the username and the audience is the tenant.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	Type      Type       `json:"typ"`
+	Role      model.Role `json:"role"`
+	StudentID string     `json:"sid,omitempty"`
+}
+
+// Principal returns the principal which the claims belong to.
+func (c Claims) Principal() Principal {
+	return Principal{
+		Username:  c.Subject,
+		Role:      c.Role,
+		StudentID: c.StudentID,
+		KeyID:     "",
+		Scopes:    nil,
+	}
+}
+
+// Pair is an access token with its refresh token.
+type Pair struct {
+	Access    string
+	Refresh   string
+	ExpiresIn time.Duration
+}
+
+// Tokens issues and parses the tokens of a tenant, they are signed with HMAC-SHA256.
+// The tokens of a tenant are not accepted by the others even when they share the secret.
+type Tokens struct {
+	Secret     []byte
+	Tenant     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// NewSecret generates a random secret for the servers which are started without one,
+// their tokens become invalid when they restart.
+func NewSecret() []byte {
+	b := make([]byte, SecretLen)
+	_, _ = rand.Read(b)
+
+	return b
+}
+
+func newID() string {
+	b := make([]byte, SecretLen/2) // nolint: mnd
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+func (t Tokens) sign(u model.User, typ Type, ttl time.Duration, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    Issuer,
+			Subject:   u.Username,
+			Audience:  jwt.ClaimStrings{t.Tenant},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			NotBefore: nil,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        newID(),
+		},
+		Type:      typ,
+		Role:      u.Role,
+		StudentID: u.StudentID,
+	})
+
+	s, err := token.SignedString(t.Secret)
+	if err != nil {
+		return "", fmt.Errorf("cannot sign the %s token %w", typ, err)
+	}
+
+	return s, nil
+}
+
+// Issue issues a new pair of tokens for the user.
+func (t Tokens) Issue(u model.User) (Pair, error) {
+	now := time.Now()
+
+	access, err := t.sign(u, TypeAccess, t.AccessTTL, now)
+	if err != nil {
+		return Pair{}, err
+	}
+
+	refresh, err := t.sign(u, TypeRefresh, t.RefreshTTL, now)
+	if err != nil {
+		return Pair{}, err
+	}
+
+	return Pair{
+		Access:    access,
+		Refresh:   refresh,
+		ExpiresIn: t.AccessTTL,
+	}, nil
+}
+
+// Parse verifies the token and returns its claims, an empty type accepts both types.
+// It doesn't check the revocation of the token.
+func (t Tokens) Parse(raw string, typ Type) (Claims, error) {
+	var claims Claims
+
+	_, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (any, error) {
+		return t.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(Issuer),
+		jwt.WithAudience(t.Tenant),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if claims.ID == "" || (typ != "" && claims.Type != typ) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	return claims, nil
+}
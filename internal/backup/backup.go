@@ -0,0 +1,185 @@
+// Package backup copies SQLite databases using the SQLite online backup API,
+// so backups can be taken while the server is running.
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/1995parham-teaching/students/internal/db"
+	"github.com/mattn/go-sqlite3"
+)
+
+// StepPages is the number of pages which are copied in each backup step,
+// the source database is only locked during a step.
+const StepPages = 256
+
+var (
+	ErrSchemaVersion = errors.New("backup schema version does not match")
+	ErrIntegrity     = errors.New("backup integrity check failed")
+	ErrNotSQLite     = errors.New("connection is not a sqlite connection")
+)
+
+// Copy copies the main database of src into the dest database file.
+func Copy(ctx context.Context, src *sql.DB, dest string) error {
+	uri, err := fileURI(dest, "")
+	if err != nil {
+		return err
+	}
+
+	destDB, err := sql.Open("sqlite3", uri)
+	if err != nil {
+		return err
+	}
+	defer destDB.Close()
+
+	return copyDB(ctx, src, destDB)
+}
+
+func copyDB(ctx context.Context, src *sql.DB, dest *sql.DB) error {
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	destConn, err := dest.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer destConn.Close()
+
+	return destConn.Raw(func(d any) error {
+		return srcConn.Raw(func(s any) error {
+			dc, ok := d.(*sqlite3.SQLiteConn)
+			if !ok {
+				return ErrNotSQLite
+			}
+
+			sc, ok := s.(*sqlite3.SQLiteConn)
+			if !ok {
+				return ErrNotSQLite
+			}
+
+			b, err := dc.Backup("main", sc, "main")
+			if err != nil {
+				return err
+			}
+
+			for {
+				done, err := b.Step(StepPages)
+				if err != nil {
+					_ = b.Finish()
+
+					return err
+				}
+
+				if done {
+					break
+				}
+
+				if ctx.Err() != nil {
+					_ = b.Finish()
+
+					return ctx.Err()
+				}
+			}
+
+			return b.Finish()
+		})
+	})
+}
+
+// fileURI returns the uri of the database file with the given query, the path is escaped
+// so its special characters (e.g. ? and #) are not parsed as a part of the uri.
+func fileURI(path string, query string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs), RawQuery: query} // nolint: exhaustruct
+
+	return u.String(), nil
+}
+
+// readOnly returns the uri which opens the database file read-only.
+func readOnly(path string) (string, error) {
+	return fileURI(path, "mode=ro")
+}
+
+// Validate checks the integrity and the schema version of the given backup file.
+func Validate(ctx context.Context, path string) error {
+	uri, err := readOnly(path)
+	if err != nil {
+		return err
+	}
+
+	bk, err := sql.Open("sqlite3", uri)
+	if err != nil {
+		return err
+	}
+	defer bk.Close()
+
+	var result string
+
+	err = bk.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result)
+	if err != nil {
+		return err
+	}
+
+	if result != "ok" {
+		return fmt.Errorf("%w: %s", ErrIntegrity, result)
+	}
+
+	version, err := db.Version(ctx, bk)
+	if err != nil {
+		return err
+	}
+
+	if version != db.SchemaVersion {
+		return fmt.Errorf("%w: expected %d, got %d", ErrSchemaVersion, db.SchemaVersion, version)
+	}
+
+	return nil
+}
+
+// Restore validates the backup and then replaces the content of the database with it.
+// The current content of the database is saved into the safety file (if given) before it is replaced.
+func Restore(ctx context.Context, path string, database string, safety string) error {
+	err := Validate(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	// the database is opened as the server opens it, so it can have the parameters of the driver.
+	live, err := sql.Open("sqlite3", database)
+	if err != nil {
+		return err
+	}
+	defer live.Close()
+
+	if safety != "" {
+		err := Copy(ctx, live, safety)
+		if err != nil {
+			return fmt.Errorf("cannot save the current database %w", err)
+		}
+	}
+
+	uri, err := readOnly(path)
+	if err != nil {
+		return err
+	}
+
+	bk, err := sql.Open("sqlite3", uri)
+	if err != nil {
+		return err
+	}
+	defer bk.Close()
+
+	return copyDB(ctx, bk, live)
+}
@@ -0,0 +1,122 @@
+package backup_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/backup"
+	"github.com/1995parham-teaching/students/internal/db"
+)
+
+func setupTestDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	d, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	t.Cleanup(func() { _ = d.Close() })
+
+	_, err = d.Exec("CREATE TABLE students (id TEXT PRIMARY KEY, name TEXT)")
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	return d
+}
+
+func TestScheduler_Rotate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	d := setupTestDB(t, filepath.Join(dir, "students.db"))
+
+	s := backup.NewScheduler(d, filepath.Join(dir, "backups"), 0, 2)
+
+	// backups are named by their creation second.
+	now := time.Now()
+	s.Clock = func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+
+	for range 3 {
+		if _, err := s.Now(context.Background()); err != nil {
+			t.Fatalf("failed to take backup: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "backups"))
+	if err != nil {
+		t.Fatalf("failed to read backups: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("expected 2 backups, got %d", len(entries))
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	database := filepath.Join(dir, "students.db")
+	d := setupTestDB(t, database)
+
+	if _, err := d.Exec("INSERT INTO students VALUES ('12345678', 'Parham Alvani')"); err != nil {
+		t.Fatalf("failed to insert student: %v", err)
+	}
+
+	// the special characters of the uris are escaped, both in the backup directory and the file name.
+	if err := os.Mkdir(filepath.Join(dir, "backups?#1"), 0o700); err != nil {
+		t.Fatalf("failed to create backup directory: %v", err)
+	}
+
+	bk := filepath.Join(dir, "backups?#1", "backup #1.db")
+
+	if err := backup.Copy(ctx, d, bk); err != nil {
+		t.Fatalf("failed to take backup: %v", err)
+	}
+
+	if _, err := os.Stat(bk); err != nil {
+		t.Fatalf("expected the backup to be written into its path: %v", err)
+	}
+
+	// backup is taken before stamping the schema version.
+	if err := backup.Restore(ctx, bk, database, ""); !errors.Is(err, backup.ErrSchemaVersion) {
+		t.Fatalf("expected ErrSchemaVersion, got %v", err)
+	}
+
+	if err := db.Stamp(ctx, d); err != nil {
+		t.Fatalf("failed to stamp schema version: %v", err)
+	}
+
+	if err := backup.Copy(ctx, d, bk); err != nil {
+		t.Fatalf("failed to take backup: %v", err)
+	}
+
+	if _, err := d.Exec("DELETE FROM students"); err != nil {
+		t.Fatalf("failed to delete students: %v", err)
+	}
+
+	if err := backup.Restore(ctx, bk, database, filepath.Join(dir, "safety.db")); err != nil {
+		t.Fatalf("failed to restore backup: %v", err)
+	}
+
+	var count int
+	if err := d.QueryRow("SELECT count(*) FROM students").Scan(&count); err != nil {
+		t.Fatalf("failed to count students: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 student after restore, got %d", count)
+	}
+}
@@ -0,0 +1,148 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	filePrefix = "students-"
+	fileSuffix = ".db"
+	timeLayout = "20060102T150405Z"
+)
+
+// Result describes a created backup file.
+type Result struct {
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Scheduler takes backups of the database into Dir periodically and keeps only
+// the Keep newest backups. Backups can also be taken on demand with Now.
+type Scheduler struct {
+	DB       *sql.DB
+	Dir      string
+	Interval time.Duration
+	Keep     int
+	// Clock returns the current time, the backups are named by it.
+	Clock func() time.Time
+
+	// lock prevents concurrent backups, e.g. scheduled and on demand.
+	lock sync.Mutex
+}
+
+func NewScheduler(db *sql.DB, dir string, interval time.Duration, keep int) *Scheduler {
+	return &Scheduler{
+		DB:       db,
+		Dir:      dir,
+		Interval: interval,
+		Keep:     keep,
+		Clock:    time.Now,
+		lock:     sync.Mutex{},
+	}
+}
+
+// Run takes backups until the context is canceled, zero interval disables the scheduled backups.
+func (s *Scheduler) Run(ctx context.Context) {
+	if s.Interval <= 0 {
+		return
+	}
+
+	t := time.NewTicker(s.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			r, err := s.Now(ctx)
+			if err != nil {
+				log.Printf("scheduled backup failed %s", err)
+
+				continue
+			}
+
+			log.Printf("scheduled backup is written into %s", r.Path)
+		}
+	}
+}
+
+// Now takes a backup and then removes the old ones.
+func (s *Scheduler) Now(ctx context.Context) (Result, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := os.MkdirAll(s.Dir, 0o750)
+	if err != nil {
+		return Result{}, err
+	}
+
+	now := s.Clock().UTC()
+	path := filepath.Join(s.Dir, filePrefix+now.Format(timeLayout)+fileSuffix)
+
+	err = Copy(ctx, s.DB, path)
+	if err != nil {
+		_ = os.Remove(path)
+
+		return Result{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Result{}, err
+	}
+
+	err = s.rotate()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Path:      path,
+		Size:      info.Size(),
+		CreatedAt: now,
+	}, nil
+}
+
+// rotate removes all the backups except the Keep newest ones,
+// backup names are sortable by their creation time.
+func (s *Scheduler) rotate() error {
+	if s.Keep <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return err
+	}
+
+	backups := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), filePrefix) && strings.HasSuffix(e.Name(), fileSuffix) {
+			backups = append(backups, e.Name())
+		}
+	}
+
+	slices.Sort(backups)
+
+	for len(backups) > s.Keep {
+		err := os.Remove(filepath.Join(s.Dir, backups[0]))
+		if err != nil {
+			return err
+		}
+
+		backups = backups[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+// Package cmd contains the command line interface of the students server,
+// running without a sub-command starts the server.
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+func Execute(ctx context.Context) error {
+	root := &cli.Command{ // nolint: exhaustruct
+		Name:           "students",
+		Usage:          "store information about students and their courses",
+		Flags:          databaseFlags(),
+		DefaultCommand: "serve",
+		Commands: []*cli.Command{
+			Serve(),
+			Restore(),
+			Seed(),
+			User(),
+		},
+	}
+
+	return root.Run(ctx, os.Args)
+}
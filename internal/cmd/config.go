@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"github.com/1995parham-teaching/students/internal/config"
+	"github.com/urfave/cli/v3"
+)
+
+// databaseFlags are the global flags of the database, all the commands use them.
+func databaseFlags() []cli.Flag {
+	d := config.Default()
+
+	return []cli.Flag{
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "config",
+			Sources: cli.EnvVars("STUDENTS_CONFIG"),
+			Usage:   "yaml file of the configuration, the environment variables and the flags override it",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "database",
+			Value:   d.Database.Path,
+			Sources: cli.EnvVars("STUDENTS_DATABASE_PATH"),
+			Usage:   "path of the sqlite database",
+		},
+		&cli.BoolFlag{ // nolint: exhaustruct
+			Name:    "database-debug",
+			Value:   d.Database.Debug,
+			Sources: cli.EnvVars("STUDENTS_DATABASE_DEBUG"),
+			Usage:   "log all the database statements",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "database-journal-mode",
+			Value:   d.Database.Pragmas.JournalMode,
+			Sources: cli.EnvVars("STUDENTS_DATABASE_JOURNAL_MODE"),
+			Usage:   "journal_mode pragma, one of delete, truncate, persist, memory, wal or off",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "database-synchronous",
+			Value:   d.Database.Pragmas.Synchronous,
+			Sources: cli.EnvVars("STUDENTS_DATABASE_SYNCHRONOUS"),
+			Usage:   "synchronous pragma, one of off, normal, full or extra",
+		},
+		&cli.DurationFlag{ // nolint: exhaustruct
+			Name:    "database-busy-timeout",
+			Value:   d.Database.Pragmas.BusyTimeout,
+			Sources: cli.EnvVars("STUDENTS_DATABASE_BUSY_TIMEOUT"),
+			Usage:   "busy_timeout pragma, how long the statements wait for the locks of other connections",
+		},
+		&cli.BoolFlag{ // nolint: exhaustruct
+			Name:    "database-foreign-keys",
+			Value:   d.Database.Pragmas.ForeignKeys,
+			Sources: cli.EnvVars("STUDENTS_DATABASE_FOREIGN_KEYS"),
+			Usage:   "foreign_keys pragma",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "tenants",
+			Value:   d.Tenants.File,
+			Sources: cli.EnvVars("STUDENTS_TENANTS_FILE"),
+			Usage:   "json file of the tenants, without it the database flag is used for a single tenant",
+		},
+	}
+}
+
+// httpFlags are the flags of the server configuration.
+func httpFlags() []cli.Flag {
+	d := config.Default()
+
+	return []cli.Flag{
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "address",
+			Value:   d.HTTP.Address,
+			Sources: cli.EnvVars("STUDENTS_HTTP_ADDRESS"),
+			Usage:   "host:port which the server listens on",
+		},
+		&cli.StringSliceFlag{ // nolint: exhaustruct
+			Name:    "cors-origin",
+			Value:   d.HTTP.CORSOrigins,
+			Sources: cli.EnvVars("STUDENTS_HTTP_CORS_ORIGINS"),
+			Usage:   "origin which can call the api from browsers, * allows all of them",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "store",
+			Value:   d.Store,
+			Sources: cli.EnvVars("STUDENTS_STORE"),
+			Usage:   "backend of the students, sql or memory (only for development)",
+		},
+		&cli.BoolFlag{ // nolint: exhaustruct
+			Name:    "graphql-playground",
+			Value:   d.GraphQL.Playground,
+			Sources: cli.EnvVars("STUDENTS_GRAPHQL_PLAYGROUND"),
+			Usage:   "serve the graphql playground on /v2/graphiql",
+		},
+	}
+}
+
+// serverFlags are the flags of the server configuration other than http.
+// nolint: funlen
+func serverFlags() []cli.Flag {
+	d := config.Default()
+
+	return []cli.Flag{
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "university",
+			Value:   d.Tenants.University,
+			Sources: cli.EnvVars("STUDENTS_TENANTS_UNIVERSITY"),
+			Usage:   "default name of the tenants",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "timezone",
+			Value:   d.Tenants.Timezone,
+			Sources: cli.EnvVars("STUDENTS_TENANTS_TIMEZONE"),
+			Usage:   "default time zone of the tenants course meetings",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "term-start",
+			Value:   d.Tenants.Term.Start,
+			Sources: cli.EnvVars("STUDENTS_TENANTS_TERM_START"),
+			Usage:   "default first day of the tenants term (yyyy-mm-dd)",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "term-end",
+			Value:   d.Tenants.Term.End,
+			Sources: cli.EnvVars("STUDENTS_TENANTS_TERM_END"),
+			Usage:   "default last day of the tenants term (yyyy-mm-dd)",
+		},
+		&cli.StringSliceFlag{ // nolint: exhaustruct
+			Name:    "term-holiday",
+			Value:   d.Tenants.Term.Holidays,
+			Sources: cli.EnvVars("STUDENTS_TENANTS_TERM_HOLIDAYS"),
+			Usage:   "default holiday of the tenants term (yyyy-mm-dd), classes are not held on holidays",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "jwt-secret",
+			Value:   d.Auth.JWTSecret,
+			Sources: cli.EnvVars("STUDENTS_AUTH_JWT_SECRET"),
+			Usage:   "secret which the tokens are signed with, a random one is generated when it is empty",
+		},
+		&cli.DurationFlag{ // nolint: exhaustruct
+			Name:    "access-token-ttl",
+			Value:   d.Auth.AccessTokenTTL,
+			Sources: cli.EnvVars("STUDENTS_AUTH_ACCESS_TOKEN_TTL"),
+			Usage:   "lifetime of the access tokens",
+		},
+		&cli.DurationFlag{ // nolint: exhaustruct
+			Name:    "refresh-token-ttl",
+			Value:   d.Auth.RefreshTokenTTL,
+			Sources: cli.EnvVars("STUDENTS_AUTH_REFRESH_TOKEN_TTL"),
+			Usage:   "lifetime of the refresh tokens",
+		},
+		&cli.StringFlag{ // nolint: exhaustruct
+			Name:    "backup-dir",
+			Value:   d.Backup.Dir,
+			Sources: cli.EnvVars("STUDENTS_BACKUP_DIR"),
+			Usage:   "directory of the database backups, each tenant has its own sub-directory",
+		},
+		&cli.DurationFlag{ // nolint: exhaustruct
+			Name:    "backup-interval",
+			Value:   d.Backup.Interval,
+			Sources: cli.EnvVars("STUDENTS_BACKUP_INTERVAL"),
+			Usage:   "interval of the scheduled backups, zero disables them",
+		},
+		&cli.IntFlag{ // nolint: exhaustruct
+			Name:    "backup-keep",
+			Value:   d.Backup.Keep,
+			Sources: cli.EnvVars("STUDENTS_BACKUP_KEEP"),
+			Usage:   "number of backups which are kept",
+		},
+		&cli.StringSliceFlag{ // nolint: exhaustruct
+			Name:    "rate-limit",
+			Value:   d.RateLimits,
+			Sources: cli.EnvVars("STUDENTS_RATE_LIMITS"),
+			Usage: "budget of each api key or ip address in a route group as group=rate/burst, " +
+				"rate is in requests per second and zero disables the limit " +
+				"(groups are default, auth, registration, graphql and ip)",
+		},
+		&cli.IntFlag{ // nolint: exhaustruct
+			Name:    "registration-concurrency",
+			Value:   d.Registration.Concurrency,
+			Sources: cli.EnvVars("STUDENTS_REGISTRATION_CONCURRENCY"),
+			Usage:   "number of the registrations which run at once in each tenant",
+		},
+		&cli.IntFlag{ // nolint: exhaustruct
+			Name:    "registration-queue",
+			Value:   d.Registration.Queue,
+			Sources: cli.EnvVars("STUDENTS_REGISTRATION_QUEUE"),
+			Usage:   "number of the registrations which wait for their turn, the others are rejected with 429",
+		},
+		&cli.DurationFlag{ // nolint: exhaustruct
+			Name:    "registration-wait",
+			Value:   d.Registration.Wait,
+			Sources: cli.EnvVars("STUDENTS_REGISTRATION_WAIT"),
+			Usage:   "how long the registrations wait for their turn before they are rejected with 429",
+		},
+		&cli.StringSliceFlag{ // nolint: exhaustruct
+			Name:    "event-sink",
+			Value:   d.Events.Sinks,
+			Sources: cli.EnvVars("STUDENTS_EVENTS_SINKS"),
+			Usage:   "sink of the domain events, one of log, file:<path> or webhook:<url>",
+		},
+		&cli.DurationFlag{ // nolint: exhaustruct
+			Name:    "event-retention",
+			Value:   d.Events.Retention,
+			Sources: cli.EnvVars("STUDENTS_EVENTS_RETENTION"),
+			Usage:   "how long the delivered events are kept in the outbox, zero keeps them forever",
+		},
+		&cli.BoolFlag{ // nolint: exhaustruct
+			Name:    "openapi-strict",
+			Value:   d.OpenAPI.Strict,
+			Sources: cli.EnvVars("STUDENTS_OPENAPI_STRICT"),
+			Usage:   "reject the responses which do not match the openapi document instead of only logging them",
+		},
+	}
+}
+
+// configure loads the configuration file and then applies the environment variables and the flags
+// which are set over it, the commands without the server flags only use its database and tenants.
+// nolint: cyclop, funlen, gocognit
+func configure(cmd *cli.Command) (config.Config, error) {
+	c, err := config.Load(cmd.String("config"))
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	if cmd.IsSet("database") {
+		c.Database.Path = cmd.String("database")
+	}
+
+	if cmd.IsSet("database-debug") {
+		c.Database.Debug = cmd.Bool("database-debug")
+	}
+
+	if cmd.IsSet("database-journal-mode") {
+		c.Database.Pragmas.JournalMode = cmd.String("database-journal-mode")
+	}
+
+	if cmd.IsSet("database-synchronous") {
+		c.Database.Pragmas.Synchronous = cmd.String("database-synchronous")
+	}
+
+	if cmd.IsSet("database-busy-timeout") {
+		c.Database.Pragmas.BusyTimeout = cmd.Duration("database-busy-timeout")
+	}
+
+	if cmd.IsSet("database-foreign-keys") {
+		c.Database.Pragmas.ForeignKeys = cmd.Bool("database-foreign-keys")
+	}
+
+	if cmd.IsSet("address") {
+		c.HTTP.Address = cmd.String("address")
+	}
+
+	if cmd.IsSet("cors-origin") {
+		c.HTTP.CORSOrigins = cmd.StringSlice("cors-origin")
+	}
+
+	if cmd.IsSet("store") {
+		c.Store = cmd.String("store")
+	}
+
+	if cmd.IsSet("graphql-playground") {
+		c.GraphQL.Playground = cmd.Bool("graphql-playground")
+	}
+
+	if cmd.IsSet("tenants") {
+		c.Tenants.File = cmd.String("tenants")
+	}
+
+	if cmd.IsSet("university") {
+		c.Tenants.University = cmd.String("university")
+	}
+
+	if cmd.IsSet("timezone") {
+		c.Tenants.Timezone = cmd.String("timezone")
+	}
+
+	if cmd.IsSet("term-start") {
+		c.Tenants.Term.Start = cmd.String("term-start")
+	}
+
+	if cmd.IsSet("term-end") {
+		c.Tenants.Term.End = cmd.String("term-end")
+	}
+
+	if cmd.IsSet("term-holiday") {
+		c.Tenants.Term.Holidays = cmd.StringSlice("term-holiday")
+	}
+
+	if cmd.IsSet("jwt-secret") {
+		c.Auth.JWTSecret = cmd.String("jwt-secret")
+	}
+
+	if cmd.IsSet("access-token-ttl") {
+		c.Auth.AccessTokenTTL = cmd.Duration("access-token-ttl")
+	}
+
+	if cmd.IsSet("refresh-token-ttl") {
+		c.Auth.RefreshTokenTTL = cmd.Duration("refresh-token-ttl")
+	}
+
+	if cmd.IsSet("backup-dir") {
+		c.Backup.Dir = cmd.String("backup-dir")
+	}
+
+	if cmd.IsSet("backup-interval") {
+		c.Backup.Interval = cmd.Duration("backup-interval")
+	}
+
+	if cmd.IsSet("backup-keep") {
+		c.Backup.Keep = cmd.Int("backup-keep")
+	}
+
+	if cmd.IsSet("rate-limit") {
+		c.RateLimits = cmd.StringSlice("rate-limit")
+	}
+
+	if cmd.IsSet("registration-concurrency") {
+		c.Registration.Concurrency = cmd.Int("registration-concurrency")
+	}
+
+	if cmd.IsSet("registration-queue") {
+		c.Registration.Queue = cmd.Int("registration-queue")
+	}
+
+	if cmd.IsSet("registration-wait") {
+		c.Registration.Wait = cmd.Duration("registration-wait")
+	}
+
+	if cmd.IsSet("event-sink") {
+		c.Events.Sinks = cmd.StringSlice("event-sink")
+	}
+
+	if cmd.IsSet("event-retention") {
+		c.Events.Retention = cmd.Duration("event-retention")
+	}
+
+	if cmd.IsSet("openapi-strict") {
+		c.OpenAPI.Strict = cmd.Bool("openapi-strict")
+	}
+
+	return c, c.Validate()
+}
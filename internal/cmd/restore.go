@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/1995parham-teaching/students/internal/backup"
+	"github.com/urfave/cli/v3"
+)
+
+var ErrMissingBackup = errors.New("backup file is required")
+
+// SafetySuffix is appended to the path of the database to save it before the restore.
+const SafetySuffix = ".pre-restore"
+
+func Restore() *cli.Command {
+	return &cli.Command{ // nolint: exhaustruct
+		Name:      "restore",
+		Usage:     "replace the database with a backup after validating its integrity and schema version",
+		ArgsUsage: "<backup>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{ // nolint: exhaustruct
+				Name:  "tenant",
+				Usage: "tenant whose database is replaced, its database is read from the tenants file",
+			},
+			&cli.StringFlag{ // nolint: exhaustruct
+				Name: "safety",
+				Usage: "where the current database is saved before it is replaced, empty to skip " +
+					"(default: the database path with the " + SafetySuffix + " suffix)",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			path := cmd.Args().First()
+			if path == "" {
+				return ErrMissingBackup
+			}
+
+			c, err := configure(cmd)
+			if err != nil {
+				return err
+			}
+
+			database := c.Database.Path
+
+			if cmd.IsSet("tenant") {
+				tenants, err := loadTenants(c)
+				if err != nil {
+					return err
+				}
+
+				t, err := tenants.Get(cmd.String("tenant"))
+				if err != nil {
+					return err
+				}
+
+				database = t.DatabasePath()
+			}
+
+			// the parameters of the driver are not a part of the database file.
+			file, _, _ := strings.Cut(database, "?")
+			safety := file + SafetySuffix
+			if cmd.IsSet("safety") {
+				safety = cmd.String("safety")
+			}
+
+			err = backup.Restore(ctx, path, database, safety)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("%s is restored into %s", path, database)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/db"
+	"github.com/1995parham-teaching/students/internal/seed"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/urfave/cli/v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func Seed() *cli.Command {
+	return &cli.Command{ // nolint: exhaustruct
+		Name:  "seed",
+		Usage: "create synthetic students, courses and enrollments",
+		Flags: []cli.Flag{
+			&cli.IntFlag{ // nolint: exhaustruct
+				Name:  "students",
+				Value: 100,
+				Usage: "number of students",
+			},
+			&cli.IntFlag{ // nolint: exhaustruct
+				Name:  "courses",
+				Value: 20,
+				Usage: "number of courses",
+			},
+			&cli.IntFlag{ // nolint: exhaustruct
+				Name:  "max-courses",
+				Value: 5,
+				Usage: "maximum number of courses of each student",
+			},
+			&cli.Uint64Flag{ // nolint: exhaustruct
+				Name:  "seed",
+				Value: 1373,
+				Usage: "seed of the generator, the same seed generates the same data on an empty database",
+			},
+			&cli.Float64Flag{ // nolint: exhaustruct
+				Name:  "persian",
+				Value: 0.5,
+				Usage: "ratio of the names which are generated in Persian",
+			},
+			&cli.StringFlag{ // nolint: exhaustruct
+				Name:  "reference",
+				Value: seed.Reference.Format(time.DateOnly),
+				Usage: "reference date (yyyy-mm-dd) of the generated data, e.g. the creation time and the entrance of the students",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			c, err := configure(cmd)
+			if err != nil {
+				return err
+			}
+
+			now, err := time.Parse(time.DateOnly, cmd.String("reference"))
+			if err != nil {
+				return fmt.Errorf("invalid reference date %w", err)
+			}
+
+			gdb, err := db.Open(c.Database.Path, c.Database.Pragmas, c.Database.Debug)
+			if err != nil {
+				return err
+			}
+
+			// don't log each insert.
+			gdb = gdb.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Warn)}) // nolint: exhaustruct
+
+			s := seed.New(student.NewSQL(gdb), course.NewSQL(gdb), cmd.Uint64("seed"), cmd.Float64("persian"), now)
+
+			r, err := s.Run(ctx, cmd.Int("students"), cmd.Int("courses"), cmd.Int("max-courses"))
+			if err != nil {
+				return err
+			}
+
+			log.Printf("%d students, %d courses and %d enrollments are created", r.Students, r.Courses, r.Enrollments)
+
+			sqlDB, err := gdb.DB()
+			if err != nil {
+				return err
+			}
+
+			return db.Stamp(ctx, sqlDB)
+		},
+	}
+}
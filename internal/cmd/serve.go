@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/config"
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/metrics"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/openapi"
+	"github.com/1995parham-teaching/students/internal/ratelimit"
+	"github.com/1995parham-teaching/students/internal/tenant"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/urfave/cli/v3"
+)
+
+var ErrTermEnd = errors.New("term must end after its start")
+
+const (
+	// GraphQLKeepAlive is the interval of the keep-alive messages of the graphql subscriptions.
+	GraphQLKeepAlive = 10 * time.Second
+	// ReadHeaderTimeout limits the time which clients have to send the request headers.
+	ReadHeaderTimeout = 10 * time.Second
+	// DefaultTenant is the tenant of the single tenant deployments.
+	DefaultTenant = "default"
+)
+
+func Serve() *cli.Command {
+	return &cli.Command{ // nolint: exhaustruct
+		Name:   "serve",
+		Usage:  "run the http server",
+		Flags:  slices.Concat(httpFlags(), serverFlags()),
+		Action: serve,
+	}
+}
+
+func serve(ctx context.Context, cmd *cli.Command) error {
+	c, err := configure(cmd)
+	if err != nil {
+		return err
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})) // nolint: exhaustruct
+
+	tenants, err := loadTenants(c)
+	if err != nil {
+		return err
+	}
+
+	// the sinks are named by their specification.
+	sinks := make(map[string]event.Sink, len(c.Events.Sinks))
+
+	for _, spec := range c.Events.Sinks {
+		sink, err := event.ParseSink(spec)
+		if err != nil {
+			return err
+		}
+
+		sinks[spec] = sink
+	}
+
+	spec, err := openapi.Spec()
+	if err != nil {
+		return err
+	}
+
+	validator, err := openapi.NewValidator(spec, c.OpenAPI.Strict)
+	if err != nil {
+		return err
+	}
+
+	secret := []byte(c.Auth.JWTSecret)
+	if len(secret) == 0 {
+		log.Println("jwt secret is not set, the tokens are invalidated when the server restarts")
+
+		secret = auth.NewSecret()
+	}
+
+	bs, err := c.Budgets()
+	if err != nil {
+		return err
+	}
+
+	s := shared{
+		HTTPMetrics:  metrics.NewHTTP(reg),
+		StoreMetrics: metrics.NewStore(reg),
+		Sinks:        sinks,
+		Spec:         spec,
+		Validator:    validator,
+		Secret:       secret,
+		Limiter:      ratelimit.New(bs),
+		Config:       c,
+	}
+
+	handlers := make(map[string]http.Handler, len(tenants.Tenants))
+
+	for _, t := range tenants.Tenants {
+		h, err := newTenant(ctx, t, s)
+		if err != nil {
+			return fmt.Errorf("tenant %s %w", t.ID, err)
+		}
+
+		handlers[t.ID] = h
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(reg))
+	mux.Handle("/", tenant.NewRouter(tenants, handlers))
+
+	srv := &http.Server{ // nolint: exhaustruct
+		Addr:              c.HTTP.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: ReadHeaderTimeout,
+	}
+
+	log.Printf("http server started on %s with %d tenants", srv.Addr, len(handlers))
+
+	return srv.ListenAndServe()
+}
+
+// loadTenants reads the tenants file, without it the server has a single default tenant
+// which uses the configured database.
+func loadTenants(c config.Config) (tenant.Config, error) {
+	if c.Tenants.File == "" {
+		return tenant.Config{
+			Default: DefaultTenant,
+			Domain:  "",
+			Tenants: []tenant.Tenant{{
+				ID:        DefaultTenant,
+				Name:      "",
+				Database:  c.Database.Path,
+				Hosts:     nil,
+				Timezone:  "",
+				TermStart: "",
+				TermEnd:   "",
+				Holidays:  nil,
+			}},
+		}, nil
+	}
+
+	return tenant.Load(c.Tenants.File)
+}
+
+// parseTerm parses the term dates as midnights in the given location.
+func parseTerm(start string, end string, holidays []string, loc *time.Location) (model.Term, error) {
+	s, err := time.ParseInLocation(config.DateLayout, start, loc)
+	if err != nil {
+		return model.Term{}, fmt.Errorf("invalid term start %w", err)
+	}
+
+	e, err := time.ParseInLocation(config.DateLayout, end, loc)
+	if err != nil {
+		return model.Term{}, fmt.Errorf("invalid term end %w", err)
+	}
+
+	if e.Before(s) {
+		return model.Term{}, ErrTermEnd
+	}
+
+	hs := make([]time.Time, 0, len(holidays))
+
+	for _, h := range holidays {
+		d, err := time.ParseInLocation(config.DateLayout, h, loc)
+		if err != nil {
+			return model.Term{}, fmt.Errorf("invalid term holiday %w", err)
+		}
+
+		hs = append(hs, d)
+	}
+
+	return model.Term{
+		Start:    s,
+		End:      e,
+		Holidays: hs,
+	}, nil
+}
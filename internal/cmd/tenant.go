@@ -0,0 +1,428 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/backup"
+	"github.com/1995parham-teaching/students/internal/config"
+	"github.com/1995parham-teaching/students/internal/db"
+	"github.com/1995parham-teaching/students/internal/dispatcher"
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/graph"
+	"github.com/1995parham-teaching/students/internal/graph/resolver"
+	"github.com/1995parham-teaching/students/internal/handler"
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/metrics"
+	"github.com/1995parham-teaching/students/internal/openapi"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/pubsub"
+	"github.com/1995parham-teaching/students/internal/ratelimit"
+	"github.com/1995parham-teaching/students/internal/store/apikey"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/outbox"
+	"github.com/1995parham-teaching/students/internal/store/sourcedid"
+	"github.com/1995parham-teaching/students/internal/store/stats"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/1995parham-teaching/students/internal/store/token"
+	"github.com/1995parham-teaching/students/internal/store/user"
+	whstore "github.com/1995parham-teaching/students/internal/store/webhook"
+	"github.com/1995parham-teaching/students/internal/tenant"
+	"github.com/1995parham-teaching/students/internal/webhook"
+	"github.com/99designs/gqlgen/graphql"
+	gHandler "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"gorm.io/gorm"
+)
+
+// shared contains what is shared between the tenants, they don't hold any tenant data.
+type shared struct {
+	HTTPMetrics  metrics.HTTP
+	StoreMetrics metrics.Store
+	Sinks        map[string]event.Sink
+	Spec         *openapi3.T
+	Validator    openapi.Validator
+	Secret       []byte
+	// Limiter is shared so a client has the same budget in all the tenants.
+	Limiter *ratelimit.Limiter
+	Config  config.Config
+}
+
+// public are the routes which don't need an access token.
+// nolint: gochecknoglobals
+var public = map[string]bool{
+	"/v1/auth/login":   true,
+	"/v1/auth/refresh": true,
+	"/v1/auth/revoke":  true,
+	"/v1/openapi.json": true,
+	"/v1/docs":         true,
+	"/v2/graphiql":     true,
+}
+
+// skipAuth skips the public routes and the graphql websockets, which are authenticated by their init message
+// because browsers cannot set headers on websockets.
+func skipAuth(c echo.Context) bool {
+	if public[c.Path()] {
+		return true
+	}
+
+	return c.Path() == "/v2/query" && strings.EqualFold(c.Request().Header.Get(echo.HeaderUpgrade), "websocket")
+}
+
+// registration are the methods and routes which register the students, they have their own rate limit budget
+// and are admitted through a queue.
+// nolint: gochecknoglobals
+var registration = map[string]bool{
+	"GET /v1/students/:sid/register/:cid":       true,
+	"DELETE /v1/students/:sid/register/:cid":    true,
+	"POST /v1/students/:sid/enrollments":        true,
+	"DELETE /v1/students/:sid/enrollments/:cid": true,
+}
+
+// registering reports whether the request registers a student.
+func registering(c echo.Context) bool {
+	return registration[c.Request().Method+" "+c.Path()]
+}
+
+// rateGroup returns the rate limit group of the route.
+func rateGroup(c echo.Context) string {
+	switch {
+	case registering(c):
+		return ratelimit.GroupRegistration
+	case strings.HasPrefix(c.Path(), "/v1/auth/"):
+		return ratelimit.GroupAuth
+	case strings.HasPrefix(c.Path(), "/v2/"):
+		return ratelimit.GroupGraphQL
+	default:
+		return ratelimit.GroupDefault
+	}
+}
+
+// rateClient identifies the clients by their api key and the others by their ip address.
+func rateClient(c echo.Context) string {
+	p, ok := auth.FromContext(c.Request().Context())
+	if ok && p.KeyID != "" {
+		return "key:" + p.KeyID
+	}
+
+	return ipClient(c)
+}
+
+// ipClient identifies the clients by their ip address.
+func ipClient(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// settings fills the empty settings of the tenant with the server defaults.
+func settings(c config.Tenants, t tenant.Tenant) tenant.Tenant {
+	if t.Name == "" {
+		t.Name = c.University
+	}
+
+	t.Database = t.DatabasePath()
+
+	if t.Timezone == "" {
+		t.Timezone = c.Timezone
+	}
+
+	if t.TermStart == "" {
+		t.TermStart = c.Term.Start
+	}
+
+	if t.TermEnd == "" {
+		t.TermEnd = c.Term.End
+	}
+
+	if t.Holidays == nil {
+		t.Holidays = c.Term.Holidays
+	}
+
+	return t
+}
+
+// students returns the students store of the backend.
+func students(backend string, gdb *gorm.DB) student.Student {
+	if backend == config.StoreMemory {
+		return student.NewInMemory()
+	}
+
+	return student.NewSQL(gdb)
+}
+
+// newTenant opens the tenant database and creates its handlers and background workers,
+// all of them only have access to the tenant stores.
+// nolint: funlen
+func newTenant(ctx context.Context, t tenant.Tenant, s shared) (http.Handler, error) {
+	t = settings(s.Config.Tenants, t)
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	// X-Forwarded-For is only trusted from the proxies on the loopback and private networks.
+	app.IPExtractor = echo.ExtractIPFromXFFHeader()
+	app.Use(middleware.RequestID())
+
+	// the preflight requests are answered before the authentication, because browsers don't send credentials on them.
+	if len(s.Config.HTTP.CORSOrigins) != 0 {
+		app.Use(middleware.CORSWithConfig(middleware.CORSConfig{ // nolint: exhaustruct
+			AllowOrigins:  s.Config.HTTP.CORSOrigins,
+			ExposeHeaders: []string{echo.HeaderLocation, echo.HeaderRetryAfter, echo.HeaderXRequestID, "Deprecation", "Link"},
+		}))
+	}
+	app.Use(s.HTTPMetrics.Middleware())
+	app.Use(i18n.Middleware())
+
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	gdb, err := db.Open(t.Database, s.Config.Database.Pragmas, s.Config.Database.Debug)
+	if err != nil {
+		return nil, err
+	}
+
+	authn := auth.Authenticator{
+		Tokens: auth.Tokens{
+			Secret:     s.Secret,
+			Tenant:     t.ID,
+			AccessTTL:  s.Config.Auth.AccessTokenTTL,
+			RefreshTTL: s.Config.Auth.RefreshTokenTTL,
+		},
+		Revoked: token.NewSQL(gdb),
+		Keys:    apikey.NewSQL(gdb),
+	}
+
+	// each ip address is limited before the authentication, so the credentials are not checked at any rate,
+	// and the groups are limited after it to identify the api keys.
+	app.Use(s.Limiter.Middleware(func(echo.Context) string { return ratelimit.GroupIP }, ipClient))
+	app.Use(authn.Middleware(skipAuth))
+	app.Use(s.Limiter.Middleware(rateGroup, rateClient))
+	app.Use(ratelimit.NewAdmission(s.Config.Registration.Concurrency, s.Config.Registration.Queue,
+		s.Config.Registration.Wait).Middleware(func(c echo.Context) bool { return !registering(c) }))
+	app.Use(s.Validator.Middleware())
+
+	{
+		h := handler.Auth{
+			Users:   user.NewSQL(gdb),
+			Tokens:  authn.Tokens,
+			Revoked: authn.Revoked,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		h := handler.APIKey{
+			Store: authn.Keys,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	ss := student.NewMetered(students(s.Config.Store, gdb), s.StoreMetrics)
+
+	{
+		h := handler.Student{
+			Store:    ss,
+			Location: loc,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		h := handler.Enrollment{
+			Store:    ss,
+			Location: loc,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		h := handler.OpenAPI{
+			Spec: s.Spec,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	sc := course.NewMetered(course.NewSQL(gdb), s.StoreMetrics)
+
+	{
+		h := handler.Course{
+			Store: sc,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		h := handler.Export{
+			Store:   ss,
+			Courses: sc,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		h := handler.OneRoster{
+			Students:   ss,
+			Courses:    sc,
+			SourcedIDs: sourcedid.NewSQL(gdb),
+			University: t.Name,
+			Location:   loc,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		term, err := parseTerm(t.TermStart, t.TermEnd, t.Holidays, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		h := handler.Schedule{
+			Students:   ss,
+			Courses:    sc,
+			Term:       term,
+			Location:   loc,
+			University: t.Name,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	st := stats.NewSQL(gdb)
+
+	{
+		h := handler.Stats{
+			Store: st,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	ws := whstore.NewSQL(gdb)
+
+	// events are published into graphql subscriptions and server-sent events through the outbox.
+	events := pubsub.New(pubsub.DefaultHistory)
+
+	{
+		h := handler.Webhook{
+			Store: ws,
+		}
+
+		h.Register(app.Group("/v1"))
+
+		go webhook.NewSender(ws).Run(ctx)
+	}
+
+	{
+		h := handler.Events{
+			Broker:    events,
+			Heartbeat: handler.DefaultHeartbeat,
+		}
+
+		h.Register(app.Group("/v1"))
+	}
+
+	{
+		sinks := make(map[string]event.Sink, len(s.Sinks)+2)
+		maps.Copy(sinks, s.Sinks)
+		sinks["webhooks"] = webhook.Sink{Store: ws}
+		sinks["events"] = events
+
+		o, err := outbox.NewSQL(gdb)
+		if err != nil {
+			return nil, err
+		}
+
+		d := dispatcher.New(o, t.ID, sinks)
+		d.Retention = s.Config.Events.Retention
+
+		go d.Run(ctx)
+	}
+
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// all the stores are migrated.
+	err = db.Stamp(ctx, sqlDB)
+	if err != nil {
+		return nil, err
+	}
+
+	{
+		bs := backup.NewScheduler(sqlDB, filepath.Join(s.Config.Backup.Dir, t.ID),
+			s.Config.Backup.Interval, s.Config.Backup.Keep)
+
+		go bs.Run(ctx)
+
+		h := handler.Admin{
+			Backup: bs,
+		}
+
+		h.Register(app.Group("/admin"))
+	}
+
+	{
+		srv := gHandler.New(graph.NewExecutableSchema(resolver.New(t.Name, loc, ss, st, events)))
+		srv.AroundOperations(resolver.Loaders)
+		srv.AddTransport(transport.Websocket{ // nolint: exhaustruct
+			KeepAlivePingInterval: GraphQLKeepAlive,
+			InitFunc: func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+				raw, ok := auth.Bearer(payload.Authorization())
+				if !ok {
+					return ctx, nil, auth.ErrUnauthenticated
+				}
+
+				ctx, err := authn.Authenticate(ctx, raw)
+
+				return ctx, nil, err
+			},
+		})
+		srv.AddTransport(transport.POST{}) // nolint: exhaustruct
+		srv.SetErrorPresenter(resolver.ErrorPresenter)
+		srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+			response := next(ctx)
+
+			// HasOperationContext checks if the given context is part of an ongoing operation
+			// Some errors can happen outside of an operation, eg json unmarshal errors.
+			if graphql.HasOperationContext(ctx) {
+				oc := graphql.GetOperationContext(ctx)
+
+				if len(response.Errors) != 0 {
+					log.Println(strings.ReplaceAll(oc.RawQuery, "\n", " "))
+				}
+			}
+
+			return response
+		})
+
+		g := app.Group("/v2")
+
+		g.POST("/query", echo.WrapHandler(srv))
+		g.GET("/query", echo.WrapHandler(srv))
+
+		if s.Config.GraphQL.Playground {
+			g.GET("/graphiql", echo.WrapHandler(playground.Handler("students-fall-2022", "/v2/query")))
+		}
+	}
+
+	return app, nil
+}
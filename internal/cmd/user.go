@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/db"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/1995parham-teaching/students/internal/store/user"
+	"github.com/urfave/cli/v3"
+)
+
+func User() *cli.Command {
+	return &cli.Command{ // nolint: exhaustruct
+		Name:  "user",
+		Usage: "manage the users which can log in",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a user, the students must be linked to their student id",
+				Flags: []cli.Flag{
+					&cli.StringFlag{ // nolint: exhaustruct
+						Name:     "username",
+						Required: true,
+					},
+					&cli.StringFlag{ // nolint: exhaustruct
+						Name:     "password",
+						Sources:  cli.EnvVars("STUDENTS_USER_PASSWORD"),
+						Required: true,
+						Usage:    "password of the user, prefer the environment variable over the flag",
+					},
+					&cli.StringFlag{ // nolint: exhaustruct
+						Name:  "role",
+						Value: string(model.RoleStudent),
+						Usage: "one of student, instructor or admin",
+					},
+					&cli.StringFlag{ // nolint: exhaustruct
+						Name:  "student-id",
+						Usage: "student of the users with the student role",
+					},
+				},
+				Action: createUser,
+			},
+		},
+	}
+}
+
+func createUser(ctx context.Context, cmd *cli.Command) error {
+	req := request.UserCreate{
+		Username:  cmd.String("username"),
+		Password:  cmd.String("password"),
+		Role:      cmd.String("role"),
+		StudentID: cmd.String("student-id"),
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	c, err := configure(cmd)
+	if err != nil {
+		return err
+	}
+
+	gdb, err := db.Open(c.Database.Path, c.Database.Pragmas, c.Database.Debug)
+	if err != nil {
+		return err
+	}
+
+	if req.StudentID != "" {
+		_, err := student.NewSQL(gdb).Get(ctx, req.StudentID)
+		if err != nil {
+			return fmt.Errorf("student %s %w", req.StudentID, err)
+		}
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	err = user.NewSQL(gdb).Create(ctx, model.User{
+		Username:     req.Username,
+		PasswordHash: hash,
+		Role:         model.Role(req.Role),
+		StudentID:    req.StudentID,
+		CreatedAt:    time.Time{},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("user %s is created with the %s role", req.Username, req.Role)
+
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.Stamp(ctx, sqlDB)
+}
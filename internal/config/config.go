@@ -0,0 +1,396 @@
+// Package config is the typed configuration of the server. The defaults are overridden by a yaml
+// (or json) file, then by the environment variables and at last by the flags, and the result is validated
+// before anything starts. The environment variables and the flags are defined by the commands.
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+	// time zone database is embedded for the systems without it (e.g. scratch containers).
+	_ "time/tzdata"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/db"
+	"github.com/1995parham-teaching/students/internal/dispatcher"
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/ratelimit"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	// StoreSQL keeps the students in the database.
+	StoreSQL = "sql"
+	// StoreMemory keeps the students in memory, they are lost on restart, their registrations don't write
+	// the domain events and the other stores (e.g. courses and users) still use the database.
+	// It is only for development.
+	StoreMemory = "memory"
+
+	// DateLayout is the layout of the term dates.
+	DateLayout = "2006-01-02"
+)
+
+var (
+	ErrInvalidAddress  = validation.NewError("validation_address", "must be a host:port address")
+	ErrInvalidOrigin   = validation.NewError("validation_origin", "must be * or a scheme://host[:port] origin")
+	ErrInvalidTimezone = validation.NewError("validation_timezone", "must be a time zone, e.g. Asia/Tehran")
+	ErrInvalidDate     = validation.NewError("validation_date", "must be a yyyy-mm-dd date")
+	ErrInvalidTermEnd  = validation.NewError("validation_term_end", "must not be before the term start")
+	ErrInvalidBudget   = validation.NewError("validation_budget", "must be group=rate/burst with a known group")
+	ErrInvalidSink     = validation.NewError("validation_sink", "must be log, file:<path> or webhook:<url>")
+)
+
+// Config is the configuration of the server, the json names are also the yaml keys.
+type Config struct {
+	HTTP     HTTP     `json:"http"`
+	Database Database `json:"database"`
+	// Store is the backend of the students, sql or memory.
+	Store   string  `json:"store"`
+	GraphQL GraphQL `json:"graphql"`
+	Tenants Tenants `json:"tenants"`
+	Auth    Auth    `json:"auth"`
+	Backup  Backup  `json:"backup"`
+	// RateLimits are the budgets of the route groups as group=rate/burst, the rate is in requests per second
+	// and zero disables the limit. The groups which are not given keep their default budget.
+	RateLimits   []string     `json:"rate_limits"`
+	Registration Registration `json:"registration"`
+	Events       Events       `json:"events"`
+	OpenAPI      OpenAPI      `json:"openapi"`
+}
+
+type HTTP struct {
+	// Address is the host:port which the server listens on.
+	Address string `json:"address"`
+	// CORSOrigins are the origins which can call the api from browsers, * allows all of them
+	// and without any origin the cross-origin requests are not allowed.
+	CORSOrigins []string `json:"cors_origins"`
+}
+
+// Database is the database of the single tenant deployments, its debug mode and pragmas
+// are also used by the tenants databases.
+type Database struct {
+	Path string `json:"path"`
+	// Debug logs all the statements.
+	Debug   bool       `json:"debug"`
+	Pragmas db.Pragmas `json:"pragmas"`
+}
+
+type GraphQL struct {
+	// Playground serves the graphql playground on /v2/graphiql.
+	Playground bool `json:"playground"`
+}
+
+// Tenants is the json file of the tenants and the defaults of their empty settings,
+// without the file the database is used for a single tenant.
+type Tenants struct {
+	File       string `json:"file"`
+	University string `json:"university"`
+	// Timezone is the time zone of the course meetings.
+	Timezone string `json:"timezone"`
+	Term     Term   `json:"term"`
+}
+
+// Term is the first and the last day of the term with its holidays as yyyy-mm-dd dates,
+// classes are not held on the holidays.
+type Term struct {
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Holidays []string `json:"holidays"`
+}
+
+type Auth struct {
+	// JWTSecret signs the tokens, a random one is generated when it is empty.
+	JWTSecret       string        `json:"jwt_secret"`
+	AccessTokenTTL  time.Duration `json:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `json:"refresh_token_ttl"`
+}
+
+// Backup is where the backups are kept, each tenant has its own sub-directory, and their schedule.
+type Backup struct {
+	Dir string `json:"dir"`
+	// Interval is the interval of the scheduled backups, zero disables them.
+	Interval time.Duration `json:"interval"`
+	// Keep is the number of the backups which are kept.
+	Keep int `json:"keep"`
+}
+
+// Registration admits the registrations of each tenant through a bounded queue.
+type Registration struct {
+	// Concurrency is the number of the registrations which run at once.
+	Concurrency int `json:"concurrency"`
+	// Queue is the number of the registrations which wait for their turn, the others are rejected.
+	Queue int `json:"queue"`
+	// Wait is how long the registrations wait for their turn before they are rejected.
+	Wait time.Duration `json:"wait"`
+}
+
+type Events struct {
+	// Sinks are the sinks of the domain events, each one is log, file:<path> or webhook:<url>.
+	Sinks []string `json:"sinks"`
+	// Retention is how long the delivered events are kept in the outbox, zero keeps them forever.
+	Retention time.Duration `json:"retention"`
+}
+
+type OpenAPI struct {
+	// Strict rejects the responses which do not match the openapi document instead of only logging them.
+	Strict bool `json:"strict"`
+}
+
+// Default returns the configuration which is used without any file, environment variable or flag.
+func Default() Config {
+	return Config{
+		HTTP: HTTP{
+			Address:     "127.0.0.1:1373",
+			CORSOrigins: nil,
+		},
+		Database: Database{
+			Path:  "students.db",
+			Debug: false,
+			Pragmas: db.Pragmas{
+				JournalMode: "delete",
+				Synchronous: "full",
+				BusyTimeout: 5 * time.Second,
+				ForeignKeys: false,
+			},
+		},
+		Store: StoreSQL,
+		GraphQL: GraphQL{
+			Playground: true,
+		},
+		Tenants: Tenants{
+			File:       "",
+			University: "Amirkabir University of Technology",
+			Timezone:   "Asia/Tehran",
+			Term: Term{
+				Start:    "2026-09-23",
+				End:      "2027-01-20",
+				Holidays: nil,
+			},
+		},
+		Auth: Auth{
+			JWTSecret:       "",
+			AccessTokenTTL:  auth.DefaultAccessTTL,
+			RefreshTokenTTL: auth.DefaultRefreshTTL,
+		},
+		Backup: Backup{
+			Dir:      "backups",
+			Interval: 0,
+			Keep:     7,
+		},
+		RateLimits: budgets(),
+		Registration: Registration{
+			Concurrency: 4,
+			Queue:       100,
+			Wait:        2 * time.Second,
+		},
+		Events: Events{
+			Sinks:     []string{"log"},
+			Retention: dispatcher.DefaultRetention,
+		},
+		OpenAPI: OpenAPI{
+			Strict: false,
+		},
+	}
+}
+
+// budgets formats the default budgets of the route groups.
+func budgets() []string {
+	bs := ratelimit.DefaultBudgets()
+
+	specs := make([]string, 0, len(bs))
+	for group, b := range bs {
+		specs = append(specs, group+"="+b.String())
+	}
+
+	slices.Sort(specs)
+
+	return specs
+}
+
+// Budgets returns the budgets of the route groups, the groups which are not given keep their default budget.
+func (c Config) Budgets() (map[string]ratelimit.Budget, error) {
+	bs := ratelimit.DefaultBudgets()
+
+	for _, spec := range c.RateLimits {
+		group, b, err := ratelimit.ParseBudget(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		bs[group] = b
+	}
+
+	return bs, nil
+}
+
+// Load reads the file over the defaults, the missing keys keep their defaults and the unknown keys are rejected.
+// Without a path it returns the defaults.
+func Load(path string) (Config, error) {
+	c := Default()
+
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot read config %w", err)
+	}
+
+	err = yaml.UnmarshalWithOptions(data, &c, yaml.DisallowUnknownField())
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse config %w", err)
+	}
+
+	return c, nil
+}
+
+func (c Config) Validate() error {
+	err := validation.ValidateStruct(&c,
+		validation.Field(&c.HTTP),
+		validation.Field(&c.Database),
+		validation.Field(&c.Store, validation.Required, validation.In(StoreSQL, StoreMemory)),
+		validation.Field(&c.Tenants),
+		validation.Field(&c.Auth),
+		validation.Field(&c.Backup),
+		validation.Field(&c.RateLimits, validation.Each(validation.By(budget))),
+		validation.Field(&c.Registration),
+		validation.Field(&c.Events),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid config %w", err)
+	}
+
+	return nil
+}
+
+func (h HTTP) Validate() error {
+	return validation.ValidateStruct(&h,
+		validation.Field(&h.Address, validation.Required, validation.By(address)),
+		validation.Field(&h.CORSOrigins, validation.Each(validation.Required, validation.By(origin))),
+	)
+}
+
+func (d Database) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Path, validation.Required),
+		validation.Field(&d.Pragmas),
+	)
+}
+
+func (t Tenants) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.University, validation.Required),
+		validation.Field(&t.Timezone, validation.Required, validation.By(timezone)),
+		validation.Field(&t.Term),
+	)
+}
+
+func (t Term) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Start, validation.Required, validation.Date(DateLayout).ErrorObject(ErrInvalidDate)),
+		validation.Field(&t.End, validation.Required, validation.Date(DateLayout).ErrorObject(ErrInvalidDate),
+			validation.When(t.End < t.Start, validation.By(func(any) error { return ErrInvalidTermEnd }))),
+		validation.Field(&t.Holidays,
+			validation.Each(validation.Required, validation.Date(DateLayout).ErrorObject(ErrInvalidDate))),
+	)
+}
+
+func (a Auth) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.AccessTokenTTL, validation.Required, validation.Min(time.Second)),
+		validation.Field(&a.RefreshTokenTTL, validation.Required, validation.Min(a.AccessTokenTTL)),
+	)
+}
+
+func (b Backup) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Dir, validation.Required),
+		validation.Field(&b.Interval, validation.Min(time.Duration(0))),
+		validation.Field(&b.Keep, validation.Required, validation.Min(1)),
+	)
+}
+
+func (r Registration) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Concurrency, validation.Required, validation.Min(1)),
+		validation.Field(&r.Queue, validation.Min(0)),
+		validation.Field(&r.Wait, validation.Min(time.Duration(0))),
+	)
+}
+
+func (e Events) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Sinks, validation.Each(validation.Required, validation.By(sink))),
+		validation.Field(&e.Retention, validation.Min(time.Duration(0))),
+	)
+}
+
+func timezone(value any) error {
+	s, _ := value.(string)
+
+	_, err := time.LoadLocation(s)
+	if err != nil {
+		return ErrInvalidTimezone
+	}
+
+	return nil
+}
+
+// budget accepts the budgets of the known route groups.
+func budget(value any) error {
+	s, _ := value.(string)
+
+	group, _, err := ratelimit.ParseBudget(s)
+	if err != nil {
+		return ErrInvalidBudget
+	}
+
+	if _, ok := ratelimit.DefaultBudgets()[group]; !ok {
+		return ErrInvalidBudget
+	}
+
+	return nil
+}
+
+func sink(value any) error {
+	s, _ := value.(string)
+
+	_, err := event.ParseSink(s)
+	if err != nil {
+		return ErrInvalidSink
+	}
+
+	return nil
+}
+
+func address(value any) error {
+	s, _ := value.(string)
+
+	_, _, err := net.SplitHostPort(s)
+	if err != nil {
+		return ErrInvalidAddress
+	}
+
+	return nil
+}
+
+// origin accepts the origins which browsers send in the Origin header.
+func origin(value any) error {
+	s, _ := value.(string)
+	if s == "*" {
+		return nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.Path != "" || u.RawQuery != "" || u.User != nil || u.Fragment != "" {
+		return ErrInvalidOrigin
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/config"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "students.yml")
+
+	err := os.WriteFile(path, []byte(`
+http:
+  address: 0.0.0.0:8080
+  cors_origins: ["https://lms.aut.ac.ir"]
+database:
+  pragmas:
+    journal_mode: wal
+    busy_timeout: 2s
+graphql:
+  playground: false
+tenants:
+  file: tenants.json
+  term:
+    holidays: ["2026-10-03"]
+auth:
+  access_token_ttl: 5m
+rate_limits: ["auth=0/1"]
+events:
+  sinks: ["log", "file:events.ndjson"]
+  retention: 72h
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.HTTP.Address != "0.0.0.0:8080" || len(c.HTTP.CORSOrigins) != 1 || c.GraphQL.Playground {
+		t.Errorf("expected the file values, got %+v", c)
+	}
+
+	if c.Database.Pragmas.JournalMode != "wal" || c.Database.Pragmas.BusyTimeout != 2*time.Second {
+		t.Errorf("expected the file pragmas, got %+v", c.Database.Pragmas)
+	}
+
+	if c.Tenants.File != "tenants.json" || len(c.Tenants.Term.Holidays) != 1 || c.Auth.AccessTokenTTL != 5*time.Minute ||
+		len(c.Events.Sinks) != 2 || c.Events.Retention != 72*time.Hour {
+		t.Errorf("expected the file server settings, got %+v", c)
+	}
+
+	// the given rate limits override their groups and the others keep their defaults.
+	bs, err := c.Budgets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bs["auth"].Rate != 0 || bs["auth"].Burst != 1 || bs["graphql"].Rate != 10 {
+		t.Errorf("expected the auth budget to be overridden, got %+v", bs)
+	}
+
+	// the missing keys keep their defaults.
+	d := config.Default()
+
+	if c.Database.Path != d.Database.Path || c.Database.Pragmas.Synchronous != d.Database.Pragmas.Synchronous ||
+		c.Store != d.Store || c.Tenants.Timezone != d.Tenants.Timezone || c.Tenants.Term.Start != d.Tenants.Term.Start ||
+		c.Auth.RefreshTokenTTL != d.Auth.RefreshTokenTTL || c.Backup != d.Backup {
+		t.Errorf("expected the defaults of the missing keys, got %+v", c)
+	}
+
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoad_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "students.yml")
+
+	err := os.WriteFile(path, []byte("http:\n  adress: 0.0.0.0:8080\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := config.Load(path); err == nil {
+		t.Error("expected the unknown key to be rejected")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		change func(c *config.Config)
+		valid  bool
+	}{
+		{"default", func(_ *config.Config) {}, true},
+		{"memory store", func(c *config.Config) { c.Store = config.StoreMemory }, true},
+		{"unknown store", func(c *config.Config) { c.Store = "redis" }, false},
+		{"any port", func(c *config.Config) { c.HTTP.Address = ":1373" }, true},
+		{"no port", func(c *config.Config) { c.HTTP.Address = "127.0.0.1" }, false},
+		{"any origin", func(c *config.Config) { c.HTTP.CORSOrigins = []string{"*"} }, true},
+		{"origin", func(c *config.Config) { c.HTTP.CORSOrigins = []string{"http://localhost:3000"} }, true},
+		{"origin with path", func(c *config.Config) { c.HTTP.CORSOrigins = []string{"https://aut.ac.ir/lms"} }, false},
+		{"origin without scheme", func(c *config.Config) { c.HTTP.CORSOrigins = []string{"aut.ac.ir"} }, false},
+		{"no database", func(c *config.Config) { c.Database.Path = "" }, false},
+		{"unknown journal mode", func(c *config.Config) { c.Database.Pragmas.JournalMode = "WAL2" }, false},
+		{"default journal mode", func(c *config.Config) { c.Database.Pragmas.JournalMode = "" }, true},
+		{"unknown synchronous", func(c *config.Config) { c.Database.Pragmas.Synchronous = "always" }, false},
+		{"negative busy timeout", func(c *config.Config) { c.Database.Pragmas.BusyTimeout = -time.Second }, false},
+		{"utc", func(c *config.Config) { c.Tenants.Timezone = "UTC" }, true},
+		{"unknown timezone", func(c *config.Config) { c.Tenants.Timezone = "Asia/Esfahan" }, false},
+		{"no university", func(c *config.Config) { c.Tenants.University = "" }, false},
+		{"invalid term start", func(c *config.Config) { c.Tenants.Term.Start = "2026-9-23" }, false},
+		{"term end before start", func(c *config.Config) { c.Tenants.Term.End = "2026-09-01" }, false},
+		{"holiday", func(c *config.Config) { c.Tenants.Term.Holidays = []string{"2026-10-03"} }, true},
+		{"invalid holiday", func(c *config.Config) { c.Tenants.Term.Holidays = []string{"1405-07-11x"} }, false},
+		{"jwt secret", func(c *config.Config) { c.Auth.JWTSecret = "secret" }, true},
+		{"no access token ttl", func(c *config.Config) { c.Auth.AccessTokenTTL = 0 }, false},
+		{"refresh before access", func(c *config.Config) { c.Auth.RefreshTokenTTL = time.Minute }, false},
+		{"no backup dir", func(c *config.Config) { c.Backup.Dir = "" }, false},
+		{"negative backup interval", func(c *config.Config) { c.Backup.Interval = -time.Hour }, false},
+		{"no backup kept", func(c *config.Config) { c.Backup.Keep = 0 }, false},
+		{"rate limit", func(c *config.Config) { c.RateLimits = []string{"registration=0/1"} }, true},
+		{"invalid rate limit", func(c *config.Config) { c.RateLimits = []string{"registration=1"} }, false},
+		{"unknown rate limit group", func(c *config.Config) { c.RateLimits = []string{"admin=1/1"} }, false},
+		{"no registration concurrency", func(c *config.Config) { c.Registration.Concurrency = 0 }, false},
+		{"negative registration queue", func(c *config.Config) { c.Registration.Queue = -1 }, false},
+		{"no event sink", func(c *config.Config) { c.Events.Sinks = nil }, true},
+		{"webhook sink", func(c *config.Config) { c.Events.Sinks = []string{"webhook:https://lms.aut.ac.ir"} }, true},
+		{"unknown event sink", func(c *config.Config) { c.Events.Sinks = []string{"kafka:events"} }, false},
+		{"negative event retention", func(c *config.Config) { c.Events.Retention = -time.Hour }, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := config.Default()
+			tc.change(&c)
+
+			err := c.Validate()
+			if tc.valid && err != nil {
+				t.Errorf("expected a valid config, got %v", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Error("expected an invalid config")
+			}
+		})
+	}
+}
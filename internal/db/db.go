@@ -0,0 +1,117 @@
+// Package db opens the SQLite database and keeps track of its schema version.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// schema lists the changes of the stores tables, a store which changes its tables must add its change
+// at the end, so the SchemaVersion is increased.
+// nolint: gochecknoglobals
+var schema = [...]string{
+	"students, courses and their registrations, the oneroster sourced ids",
+	"outbox of the domain events",
+	"webhooks and their deliveries",
+	"capacity of the courses",
+	"weekly meetings of the courses",
+	"entrance and creation time of the students",
+	"profile of the students",
+	"users and the revoked tokens",
+	"instructor of the courses",
+	"api keys",
+	"registration time of the enrollments",
+}
+
+// SchemaVersion is stored as the SQLite user_version of the database, it is the number of the schema changes
+// so backups of an older schema are not restored.
+const SchemaVersion = len(schema)
+
+// Pragmas are set on each connection of the database, the empty ones keep the SQLite defaults.
+type Pragmas struct {
+	// JournalMode is one of delete, truncate, persist, memory, wal or off.
+	JournalMode string `json:"journal_mode"`
+	// Synchronous is one of off, normal, full or extra.
+	Synchronous string `json:"synchronous"`
+	// BusyTimeout is how long the statements wait for the locks of other connections.
+	BusyTimeout time.Duration `json:"busy_timeout"`
+	ForeignKeys bool          `json:"foreign_keys"`
+}
+
+func (p Pragmas) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.JournalMode, validation.In("delete", "truncate", "persist", "memory", "wal", "off")),
+		validation.Field(&p.Synchronous, validation.In("off", "normal", "full", "extra")),
+		validation.Field(&p.BusyTimeout, validation.Min(time.Duration(0))),
+	)
+}
+
+// dsn adds the pragmas to the path as the parameters of the sqlite driver.
+func (p Pragmas) dsn(path string) string {
+	params := url.Values{}
+
+	if p.JournalMode != "" {
+		params.Set("_journal_mode", p.JournalMode)
+	}
+
+	if p.Synchronous != "" {
+		params.Set("_synchronous", p.Synchronous)
+	}
+
+	params.Set("_busy_timeout", strconv.FormatInt(p.BusyTimeout.Milliseconds(), 10))
+	params.Set("_foreign_keys", strconv.FormatBool(p.ForeignKeys))
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	return path + sep + params.Encode()
+}
+
+// Open opens the database with the pragmas, in debug mode all the statements are logged.
+func Open(path string, pragmas Pragmas, debug bool) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(pragmas.dsn(path)), &gorm.Config{ // nolint: exhaustruct
+		// translate constraint errors into gorm.ErrDuplicatedKey.
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if debug {
+		return db.Debug(), nil
+	}
+
+	return db, nil
+}
+
+// Version returns the schema version of the given database.
+func Version(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+
+	err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// Stamp sets the current schema version on the database, it must be called after
+// the stores have migrated their tables.
+func Stamp(ctx context.Context, db *sql.DB) error {
+	// pragma statements do not accept parameters.
+	_, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion))
+
+	return err
+}
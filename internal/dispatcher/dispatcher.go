@@ -0,0 +1,158 @@
+// Package dispatcher delivers the outbox events to the sinks at least once,
+// failed events are retried with exponential backoff on the sinks which haven't accepted them.
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/store/outbox"
+)
+
+const (
+	DefaultInterval = time.Second
+	DefaultBatch    = 100
+	// DefaultRetention is how long the delivered events are kept in the outbox.
+	DefaultRetention = 7 * 24 * time.Hour
+	// PruneInterval is the interval of removing the delivered events which are older than the retention.
+	PruneInterval = time.Hour
+
+	minBackoff = time.Second
+	maxBackoff = 10 * time.Minute
+)
+
+// Dispatcher delivers the events of a single outbox, Tenant is set on each event
+// so the sinks which are shared between tenants can tell them apart.
+type Dispatcher struct {
+	Outbox outbox.Outbox
+	// Sinks are keyed by their names, which are recorded for the failed events to skip the sinks
+	// which have accepted them.
+	Sinks    map[string]event.Sink
+	Tenant   string
+	Interval time.Duration
+	Batch    int
+	// Retention is how long the delivered events are kept, zero keeps them forever.
+	Retention time.Duration
+}
+
+func New(o outbox.Outbox, tenant string, sinks map[string]event.Sink) Dispatcher {
+	return Dispatcher{
+		Outbox:    o,
+		Sinks:     sinks,
+		Tenant:    tenant,
+		Interval:  DefaultInterval,
+		Batch:     DefaultBatch,
+		Retention: DefaultRetention,
+	}
+}
+
+// Run polls the outbox and prunes its delivered events until the context is canceled.
+func (d Dispatcher) Run(ctx context.Context) {
+	t := time.NewTicker(d.Interval)
+	defer t.Stop()
+
+	// prune is nil without retention, so it never fires.
+	var prune <-chan time.Time
+
+	if d.Retention > 0 {
+		p := time.NewTicker(PruneInterval)
+		defer p.Stop()
+
+		prune = p.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			err := d.Dispatch(ctx)
+			if err != nil {
+				log.Printf("event dispatch failed %s", err)
+			}
+		case <-prune:
+			err := d.Prune(ctx)
+			if err != nil {
+				log.Printf("event prune failed %s", err)
+			}
+		}
+	}
+}
+
+// Prune removes the events which are delivered before the retention.
+func (d Dispatcher) Prune(ctx context.Context) error {
+	_, err := d.Outbox.Prune(ctx, time.Now().Add(-d.Retention))
+
+	return err
+}
+
+// Dispatch delivers a batch of the pending events. An event is delivered when all the sinks
+// accept it, otherwise it is retried later only on the sinks which haven't accepted it,
+// so the other sinks don't receive it again.
+func (d Dispatcher) Dispatch(ctx context.Context) error {
+	messages, err := d.Outbox.Pending(ctx, d.Batch)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		e := m.Event
+		e.Tenant = d.Tenant
+
+		delivered := m.Delivered
+
+		var errs []error
+
+		for _, name := range slices.Sorted(maps.Keys(d.Sinks)) {
+			if slices.Contains(m.Delivered, name) {
+				continue
+			}
+
+			err := d.Sinks[name].Deliver(ctx, e)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+
+				continue
+			}
+
+			delivered = append(delivered, name)
+		}
+
+		if len(errs) != 0 {
+			err := d.Outbox.MarkFailed(ctx, e.ID, delivered, errors.Join(errs...), time.Now().Add(Backoff(m.Attempts)))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := d.Outbox.MarkDelivered(ctx, e.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Backoff returns the delay before the next delivery of an event, the delay is doubled
+// on each failed attempt.
+func Backoff(attempts int) time.Duration {
+	d := minBackoff
+
+	for range attempts {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,159 @@
+package dispatcher_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/dispatcher"
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/outbox"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errDown = errors.New("sink is down")
+
+// sink records the delivered events and fails while it is down.
+type sink struct {
+	down   bool
+	events []event.Event
+}
+
+func (s *sink) Deliver(_ context.Context, e event.Event) error {
+	if s.down {
+		return errDown
+	}
+
+	s.events = append(s.events, e)
+
+	return nil
+}
+
+func TestDispatch(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{ //nolint:exhaustruct
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	ctx := context.Background()
+
+	cs := course.NewSQL(db)
+	ss := student.NewSQL(db)
+
+	err = cs.Create(ctx, model.Course{ID: "00000001", Name: "Internet Engineering"})
+	if err != nil {
+		t.Fatalf("failed to create course: %v", err)
+	}
+
+	err = ss.Create(ctx, model.Student{ID: "00000001", Name: "Parham Alvani", Courses: nil})
+	if err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	// the second registration is ignored, so it has no event.
+	for range 2 {
+		err = ss.Register(ctx, "00000001", "00000001")
+		if err != nil {
+			t.Fatalf("failed to register student: %v", err)
+		}
+	}
+
+	o, err := outbox.NewSQL(db)
+	if err != nil {
+		t.Fatalf("failed to create outbox: %v", err)
+	}
+
+	// the events are retried only on the sink which is down, so the other one doesn't receive them again.
+	up := &sink{down: false, events: nil}
+	s := &sink{down: true, events: nil}
+	d := dispatcher.New(o, "", map[string]event.Sink{"up": up, "down": s})
+
+	err = d.Dispatch(ctx)
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+
+	// failed events are retried after the backoff, so there is nothing pending now.
+	messages, err := o.Pending(ctx, dispatcher.DefaultBatch)
+	if err != nil {
+		t.Fatalf("failed to read pending events: %v", err)
+	}
+
+	if len(messages) != 0 {
+		t.Fatalf("expected no pending events during backoff, got %d", len(messages))
+	}
+
+	err = db.Model(new(outbox.SQLItem)).Where("1 = 1").Update("retry_at", gorm.Expr("created_at")).Error
+	if err != nil {
+		t.Fatalf("failed to reset the backoff: %v", err)
+	}
+
+	s.down = false
+
+	err = d.Dispatch(ctx)
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+
+	want := []event.Type{event.CourseCreated, event.StudentCreated, event.StudentRegistered}
+
+	if len(s.events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(s.events))
+	}
+
+	for i, e := range s.events {
+		if e.Type != want[i] {
+			t.Errorf("expected event %d to be %s, got %s", i, want[i], e.Type)
+		}
+	}
+
+	err = d.Dispatch(ctx)
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+
+	if len(s.events) != len(want) {
+		t.Errorf("expected delivered events not to be dispatched again, got %d events", len(s.events))
+	}
+
+	if len(up.events) != len(want) {
+		t.Errorf("expected the events to be delivered once to the sink which was up, got %d events", len(up.events))
+	}
+
+	// the delivered events are kept until the retention passes.
+	err = d.Prune(ctx)
+	if err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+
+	n, err := o.Prune(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+
+	if n != len(want) {
+		t.Errorf("expected %d delivered events to be pruned, got %d", len(want), n)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	if dispatcher.Backoff(0) >= dispatcher.Backoff(1) {
+		t.Error("expected backoff to grow with attempts")
+	}
+
+	if dispatcher.Backoff(100) != dispatcher.Backoff(50) {
+		t.Error("expected backoff to be capped")
+	}
+}
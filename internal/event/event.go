@@ -0,0 +1,90 @@
+// Package event defines the domain events which are written into the outbox
+// and delivered to the sinks.
+package event
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Type string
+
+const (
+	StudentCreated      Type = "StudentCreated"
+	CourseCreated       Type = "CourseCreated"
+	StudentRegistered   Type = "StudentRegistered"
+	StudentUnregistered Type = "StudentUnregistered"
+)
+
+// Types returns all the event types.
+func Types() []Type {
+	return []Type{StudentCreated, CourseCreated, StudentRegistered, StudentUnregistered}
+}
+
+// Event is the envelope of domain events, ID is assigned by the outbox and
+// increases with the events order. Tenant is set by the dispatcher because
+// each tenant has its own outbox.
+type Event struct {
+	ID        uint64          `json:"id"`
+	Tenant    string          `json:"tenant,omitempty"`
+	Type      Type            `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Student is the payload of StudentCreated.
+type Student struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Course is the payload of CourseCreated.
+type Course struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Registration is the payload of StudentRegistered and StudentUnregistered.
+type Registration struct {
+	StudentID string `json:"student_id"`
+	CourseID  string `json:"course_id"`
+}
+
+func New(t Type, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:        0,
+		Tenant:    "",
+		Type:      t,
+		Payload:   data,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Subjects returns the student and the course of the event, they are empty when
+// the event is not about a student or a course.
+func (e Event) Subjects() (string, string) {
+	switch e.Type {
+	case StudentCreated:
+		var s Student
+		if json.Unmarshal(e.Payload, &s) == nil {
+			return s.ID, ""
+		}
+	case CourseCreated:
+		var c Course
+		if json.Unmarshal(e.Payload, &c) == nil {
+			return "", c.ID
+		}
+	case StudentRegistered, StudentUnregistered:
+		var r Registration
+		if json.Unmarshal(e.Payload, &r) == nil {
+			return r.StudentID, r.CourseID
+		}
+	}
+
+	return "", ""
+}
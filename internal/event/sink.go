@@ -0,0 +1,127 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookTimeout is the timeout of each webhook delivery.
+const WebhookTimeout = 10 * time.Second
+
+var (
+	ErrUnknownSink = errors.New("unknown event sink")
+	ErrWebhook     = errors.New("webhook responded with non-2xx status")
+)
+
+// Sink receives the events from the dispatcher, deliveries are at least once so
+// sinks may receive an event more than once.
+type Sink interface {
+	Deliver(ctx context.Context, e Event) error
+}
+
+// ParseSink creates a sink from its specification, which is one of log, file:<path> or webhook:<url>.
+func ParseSink(spec string) (Sink, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+
+	switch kind {
+	case "log":
+		return Log{}, nil
+	case "file":
+		return NewFile(arg), nil
+	case "webhook":
+		return NewWebhook(arg), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSink, spec)
+	}
+}
+
+// Log writes events into the standard logger.
+type Log struct{}
+
+func (Log) Deliver(_ context.Context, e Event) error {
+	log.Printf("event %d %s of tenant %q %s", e.ID, e.Type, e.Tenant, e.Payload)
+
+	return nil
+}
+
+// File appends events as json lines into a file.
+type File struct {
+	Path string
+
+	lock *sync.Mutex
+}
+
+func NewFile(path string) File {
+	return File{
+		Path: path,
+		lock: new(sync.Mutex),
+	}
+}
+
+func (f File) Deliver(_ context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+
+	return err
+}
+
+// Webhook posts events as json into a url.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhook(url string) Webhook {
+	return Webhook{
+		URL:    url,
+		Client: &http.Client{Timeout: WebhookTimeout}, // nolint: exhaustruct
+	}
+}
+
+func (w Webhook) Deliver(ctx context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", ErrWebhook, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package export writes tabular data as csv, newline delimited json or xlsx
+// row by row, so exports don't need the whole table in memory.
+package export
+
+import (
+	"errors"
+	"mime"
+	"strings"
+)
+
+type Format string
+
+const (
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+	XLSX   Format = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// nolint: gochecknoglobals
+var contentTypes = map[Format]string{
+	CSV:    "text/csv",
+	NDJSON: "application/x-ndjson",
+	XLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// nolint: gochecknoglobals
+var aliases = map[string]Format{
+	"text/csv":                 CSV,
+	"application/x-ndjson":     NDJSON,
+	"application/jsonl":        NDJSON,
+	"application/json-lines":   NDJSON,
+	"application/jsonlines":    NDJSON,
+	contentTypes[XLSX]:         XLSX,
+	"application/vnd.ms-excel": XLSX,
+}
+
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Extension returns the file extension of the format, e.g. for content-disposition.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Negotiate selects the format based on the format query parameter and then the accept header.
+// Missing or wildcard accept header selects csv.
+func Negotiate(format string, accept string) (Format, error) {
+	if format != "" {
+		f := Format(strings.ToLower(format))
+		if _, ok := contentTypes[f]; !ok {
+			return "", ErrUnsupportedFormat
+		}
+
+		return f, nil
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return CSV, nil
+	}
+
+	for r := range strings.SplitSeq(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(r))
+		if err != nil {
+			continue
+		}
+
+		if f, ok := aliases[mt]; ok {
+			return f, nil
+		}
+
+		if mt == "*/*" || mt == "text/*" {
+			return CSV, nil
+		}
+	}
+
+	return "", ErrUnsupportedFormat
+}
@@ -0,0 +1,172 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer writes rows of an export, rows must have the same length as
+// the columns which are given on writer creation. Close must be called to flush the export.
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+func NewWriter(f Format, w io.Writer, columns []string) (Writer, error) {
+	switch f {
+	case CSV:
+		return newCSVWriter(w, columns)
+	case NDJSON:
+		return newNDJSONWriter(w, columns), nil
+	case XLSX:
+		return newXLSXWriter(w, columns)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (csvWriter, error) {
+	cw := csvWriter{
+		w: csv.NewWriter(w),
+	}
+
+	err := cw.w.Write(columns)
+	if err != nil {
+		return csvWriter{}, err
+	}
+
+	return cw, nil
+}
+
+func (cw csvWriter) Write(row []string) error {
+	return cw.w.Write(row)
+}
+
+func (cw csvWriter) Close() error {
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+// ndjsonWriter writes each row as a json object with the columns as its keys,
+// keys are written in the columns order.
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns [][]byte
+}
+
+func newNDJSONWriter(w io.Writer, columns []string) ndjsonWriter {
+	keys := make([][]byte, len(columns))
+
+	for i, c := range columns {
+		// marshaling a string never fails.
+		keys[i], _ = json.Marshal(c)
+	}
+
+	return ndjsonWriter{
+		w:       bufio.NewWriter(w),
+		columns: keys,
+	}
+}
+
+func (nw ndjsonWriter) Write(row []string) error {
+	_ = nw.w.WriteByte('{')
+
+	for i, v := range row {
+		if i > 0 {
+			_ = nw.w.WriteByte(',')
+		}
+
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		_, _ = nw.w.Write(nw.columns[i])
+		_ = nw.w.WriteByte(':')
+		_, _ = nw.w.Write(value)
+	}
+
+	_, err := nw.w.WriteString("}\n")
+
+	return err
+}
+
+func (nw ndjsonWriter) Close() error {
+	return nw.w.Flush()
+}
+
+// xlsxWriter uses the excelize stream writer which keeps rows in a temporary file
+// instead of memory, the workbook itself can only be written on close.
+type xlsxWriter struct {
+	w    io.Writer
+	f    *excelize.File
+	sw   *excelize.StreamWriter
+	next int
+}
+
+const xlsxSheet = "Sheet1"
+
+func newXLSXWriter(w io.Writer, columns []string) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+
+	sw, err := f.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	xw := &xlsxWriter{
+		w:    w,
+		f:    f,
+		sw:   sw,
+		next: 1,
+	}
+
+	err = xw.Write(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return xw, nil
+}
+
+func (xw *xlsxWriter) Write(row []string) error {
+	cell, err := excelize.CoordinatesToCellName(1, xw.next)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+
+	err = xw.sw.SetRow(cell, values)
+	if err != nil {
+		return fmt.Errorf("cannot write xlsx row %d %w", xw.next, err)
+	}
+
+	xw.next++
+
+	return nil
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.f.Close()
+
+	err := xw.sw.Flush()
+	if err != nil {
+		return err
+	}
+
+	return xw.f.Write(xw.w)
+}
@@ -7,3 +7,8 @@ type Mutation struct {
 
 type Query struct {
 }
+
+// Subscriptions are served over websocket (graphql-ws and graphql-transport-ws) on /v2/query,
+// a subscriber which doesn't keep up with the events is disconnected.
+type Subscription struct {
+}
@@ -1,3 +1,10 @@
 package resolver
 
-const StudentIDMax = 100_000_000
+import "errors"
+
+const (
+	StudentIDMax  = 100_000_000
+	MaxStatsPairs = 100
+)
+
+var ErrInvalidPairs = errors.New("pairs must be between 0 and 100")
@@ -0,0 +1,46 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/privacy"
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// PII implements the pii directive on the student fields, the fields are null for the callers
+// which cannot see the PII of the student.
+func PII(ctx context.Context, obj any, next graphql.Resolver) (any, error) {
+	st, ok := obj.(*model.Student)
+	if !ok || !privacy.PII(ctx, st.ID) {
+		return nil, nil
+	}
+
+	return next(ctx)
+}
+
+// HasRole implements the hasRole directive, only the callers with one of the roles or the api keys
+// with the scope can resolve the fields.
+func HasRole(ctx context.Context, _ any, next graphql.Resolver, roles []model.Role, scope *string) (any, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	if p.Scoped() {
+		if scope == nil || !policy.Allowed(p, policy.Action(*scope), policy.Any) {
+			return nil, policy.ErrForbidden
+		}
+
+		return next(ctx)
+	}
+
+	if !slices.Contains(roles, p.Role) {
+		return nil, policy.ErrForbidden
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,92 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/99designs/gqlgen/graphql"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+var ErrInternal = errors.New("internal error")
+
+// clientErrors are the errors of the resolvers which are reported to the clients, the other errors
+// may expose the database so they are logged and the clients only see ErrInternal.
+// nolint: gochecknoglobals
+var clientErrors = []error{
+	auth.ErrUnauthenticated,
+	policy.ErrForbidden,
+	ErrInvalidPairs,
+	student.ErrStudentAlreadyExists,
+	student.ErrStudentNotFound,
+	student.ErrStudentNotRegistered,
+	student.ErrNationalIDTaken,
+	course.ErrCourseAlreadyExists,
+	course.ErrCourseNotFound,
+	course.ErrCourseFull,
+}
+
+// ErrorPresenter translates the error messages into the language of the request, the authentication
+// and authorization errors have the UNAUTHENTICATED and FORBIDDEN codes in their extensions.
+// The errors which are not in clientErrors are masked like problem.Internal.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	e := graphql.DefaultErrorPresenter(ctx, err)
+	lang := i18n.FromContext(ctx)
+
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		code(e, "UNAUTHENTICATED")
+	case errors.Is(err, policy.ErrForbidden):
+		code(e, "FORBIDDEN")
+	case !client(err):
+		log.Println(err)
+
+		e.Err = ErrInternal
+		code(e, "INTERNAL_SERVER_ERROR")
+	}
+
+	if e.Err != nil {
+		e.Message = i18n.Error(lang, e.Err)
+	} else {
+		e.Message = i18n.T(lang, e.Message)
+	}
+
+	return e
+}
+
+// client reports whether the error can be shown to the clients, i.e. the errors of gqlgen itself
+// (e.g. the invalid arguments), the validation errors and clientErrors.
+func client(err error) bool {
+	var (
+		gerr *gqlerror.Error
+		verr validation.Errors
+		ferr validation.Error
+	)
+
+	if errors.As(err, &gerr) || errors.As(err, &verr) || errors.As(err, &ferr) {
+		return true
+	}
+
+	for _, e := range clientErrors {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func code(e *gqlerror.Error, c string) {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]any)
+	}
+
+	e.Extensions["code"] = c
+}
@@ -0,0 +1,45 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/graph/resolver"
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestErrorPresenter(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		err     error
+		message string
+		code    any
+	}{
+		{"domain", fmt.Errorf("loading: %w", student.ErrStudentNotFound), "loading: student does not exist", nil},
+		{"forbidden", policy.ErrForbidden, "permission denied", "FORBIDDEN"},
+		{"gqlgen", gqlerror.Errorf("SPRING is not a valid Semester"), "SPRING is not a valid Semester", nil},
+		{"database", errors.New("no such table: students"), "internal error", "INTERNAL_SERVER_ERROR"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := graphql.WithResponseContext(i18n.WithLang(context.Background(), i18n.English),
+				resolver.ErrorPresenter, graphql.DefaultRecover)
+
+			e := resolver.ErrorPresenter(ctx, tc.err)
+			if e.Message != tc.message || e.Extensions["code"] != tc.code {
+				t.Errorf("expected %q with code %v, got %q with code %v", tc.message, tc.code, e.Message, e.Extensions["code"])
+			}
+		})
+	}
+}
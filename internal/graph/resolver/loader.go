@@ -0,0 +1,64 @@
+package resolver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/stats"
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// courseStats loads the enrollment of all the courses once per operation, so the enrolled and fillRate
+// fields of the courses in a list don't query the database for each course.
+type courseStats struct {
+	once    sync.Once
+	courses map[string]model.CourseStats
+	err     error
+}
+
+type loaderKey struct{}
+
+// Loaders adds the loaders of an operation to its context.
+func Loaders(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(context.WithValue(ctx, loaderKey{}, new(courseStats)))
+}
+
+// course returns the enrollment of the course, it is loaded with the other courses of the operation
+// and without the loaders (e.g. in tests) it is loaded alone.
+func (r *courseResolver) course(ctx context.Context, id string) (model.CourseStats, error) {
+	l, ok := ctx.Value(loaderKey{}).(*courseStats)
+	if !ok {
+		return r.Stats.Course(ctx, id)
+	}
+
+	l.once.Do(func() {
+		l.courses, l.err = load(ctx, r.Stats)
+	})
+
+	if l.err != nil {
+		return model.CourseStats{}, l.err
+	}
+
+	s, ok := l.courses[id]
+	if !ok {
+		return model.CourseStats{}, course.ErrCourseNotFound
+	}
+
+	return s, nil
+}
+
+func load(ctx context.Context, st stats.Stats) (map[string]model.CourseStats, error) {
+	ss, err := st.PerCourse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := make(map[string]model.CourseStats, len(ss))
+	for _, s := range ss {
+		courses[s.Course.ID] = s
+	}
+
+	return courses, nil
+}
@@ -1,7 +1,11 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/1995parham-teaching/students/internal/graph"
+	"github.com/1995parham-teaching/students/internal/pubsub"
+	"github.com/1995parham-teaching/students/internal/store/stats"
 	"github.com/1995parham-teaching/students/internal/store/student"
 )
 
@@ -10,21 +14,37 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	Store student.Student
+	University string
+	// Location is the time zone of the rendered times.
+	Location *time.Location
+	Store    student.Student
+	Stats    stats.Stats
+	Events   *pubsub.Broker
 }
 
-func NewResolver(store student.Student) *Resolver {
+func NewResolver(
+	university string, loc *time.Location, store student.Student, st stats.Stats, events *pubsub.Broker,
+) *Resolver {
 	return &Resolver{
-		Store: store,
+		University: university,
+		Location:   loc,
+		Store:      store,
+		Stats:      st,
+		Events:     events,
 	}
 }
 
-func New(store student.Student) graph.Config {
+func New(
+	university string, loc *time.Location, store student.Student, st stats.Stats, events *pubsub.Broker,
+) graph.Config {
 	// nolint: exhaustruct
 	c := graph.Config{
-		Schema:     nil,
-		Resolvers:  NewResolver(store),
-		Directives: graph.DirectiveRoot{},
+		Schema:    nil,
+		Resolvers: NewResolver(university, loc, store, st, events),
+		Directives: graph.DirectiveRoot{
+			Pii:     PII,
+			HasRole: HasRole,
+		},
 	}
 
 	return c
@@ -3,34 +3,66 @@ package resolver
 // This file will be automatically regenerated based on the schema, any resolver
 // implementations
 // will be copied through when generating and any unknown code will be moved to the end.
-// Code generated by github.com/99designs/gqlgen version v0.17.92
+// Code generated by github.com/99designs/gqlgen version v0.17.94
 
 import (
 	"context"
 	"fmt"
+	"log"
 	rand "math/rand/v2"
+	"time"
 
+	"github.com/1995parham-teaching/students/internal/event"
 	"github.com/1995parham-teaching/students/internal/graph"
+	"github.com/1995parham-teaching/students/internal/jalali"
 	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/names"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/pubsub"
 	"github.com/1995parham-teaching/students/internal/request"
 )
 
+// Enrolled is the resolver for the enrolled field.
+func (r *courseResolver) Enrolled(ctx context.Context, obj *model.Course) (int, error) {
+	s, err := r.course(ctx, obj.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Enrolled, nil
+}
+
+// FillRate is the resolver for the fillRate field.
+func (r *courseResolver) FillRate(ctx context.Context, obj *model.Course) (*float64, error) {
+	s, err := r.course(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FillRate, nil
+}
+
 // CreateStudent is the resolver for the createStudent field.
-func (r *mutationResolver) CreateStudent(ctx context.Context, name string) (*model.Student, error) {
+func (r *mutationResolver) CreateStudent(ctx context.Context, name string, entranceYear *int, entranceSemester *model.Semester) (*model.Student, error) {
+	// nolint: exhaustruct
 	req := request.StudentCreate{
 		Name: name,
 	}
 
+	if entranceYear != nil {
+		req.EntranceYear = *entranceYear
+	}
+
+	if entranceSemester != nil {
+		req.EntranceSemester = string(*entranceSemester)
+	}
+
 	err := req.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	st := model.Student{
-		Name:    req.Name,
-		ID:      fmt.Sprintf("%08d", rand.Int64()%StudentIDMax), // nolint: gosec
-		Courses: nil,
-	}
+	st := req.Student(fmt.Sprintf("%08d", rand.Int64()%StudentIDMax), time.Now().In(r.Location)) // nolint: gosec
 
 	err = r.Store.Create(ctx, st)
 	if err != nil {
@@ -42,7 +74,7 @@ func (r *mutationResolver) CreateStudent(ctx context.Context, name string) (*mod
 
 // University is the resolver for the university field.
 func (r *queryResolver) University(ctx context.Context) (string, error) {
-	return "Amirkabir University of Technology", nil
+	return r.Resolver.University, nil
 }
 
 // StudentsByName is the resolver for the studentsByName field.
@@ -53,14 +85,13 @@ func (r *queryResolver) StudentsByName(ctx context.Context, name string) ([]*mod
 	}
 
 	response := make([]*model.Student, 0)
+	name = names.Normalize(name)
 
 	for _, student := range students {
 		if student.Name == name {
-			response = append(response, &model.Student{
-				Courses: nil,
-				Name:    student.Name,
-				ID:      student.ID,
-			})
+			student.Courses = nil
+
+			response = append(response, &student)
 		}
 	}
 
@@ -69,6 +100,11 @@ func (r *queryResolver) StudentsByName(ctx context.Context, name string) ([]*mod
 
 // StudentByID is the resolver for the studentByID field.
 func (r *queryResolver) StudentByID(ctx context.Context, id string) (*model.Student, error) {
+	err := policy.Check(ctx, policy.ReadStudents, policy.Student(id))
+	if err != nil {
+		return nil, err
+	}
+
 	s, err := r.Store.Get(ctx, id)
 	if err != nil {
 		return nil, err
@@ -77,25 +113,142 @@ func (r *queryResolver) StudentByID(ctx context.Context, id string) (*model.Stud
 	courses := make([]model.Course, 0)
 	for _, c := range s.Courses {
 		courses = append(courses, model.Course{
-			ID:   c.ID,
-			Name: c.Name,
+			ID:         c.ID,
+			Name:       c.Name,
+			Capacity:   c.Capacity,
+			Instructor: c.Instructor,
 		})
 	}
 
-	return &model.Student{
-		ID:      s.ID,
-		Name:    s.Name,
-		Courses: courses,
-	}, nil
+	s.Courses = courses
+
+	return &s, nil
+}
+
+// Stats is the resolver for the stats field.
+func (r *queryResolver) Stats(ctx context.Context, pairs int) (*model.Stats, error) {
+	if pairs < 0 || pairs > MaxStatsPairs {
+		return nil, ErrInvalidPairs
+	}
+
+	s, err := r.Resolver.Stats.Summary(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// EntranceYear is the resolver for the entranceYear field.
+func (r *studentResolver) EntranceYear(ctx context.Context, obj *model.Student) (*int, error) {
+	// students which are created before the entrance year don't have it.
+	if obj.Entrance.Year == 0 {
+		return nil, nil
+	}
+
+	return &obj.Entrance.Year, nil
+}
+
+// EntranceSemester is the resolver for the entranceSemester field.
+func (r *studentResolver) EntranceSemester(ctx context.Context, obj *model.Student) (*model.Semester, error) {
+	if obj.Entrance.Semester == "" {
+		return nil, nil
+	}
+
+	return &obj.Entrance.Semester, nil
 }
 
 // Enterance is the resolver for the enterance field.
 func (r *studentResolver) Enterance(ctx context.Context, obj *model.Student) (*int, error) {
-	enterance := 1401
+	return r.EntranceYear(ctx, obj)
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *studentResolver) CreatedAt(ctx context.Context, obj *model.Student, calendar jalali.Calendar) (string, error) {
+	return calendar.Format(obj.CreatedAt.In(r.Location)), nil
+}
+
+// BirthDate is the resolver for the birthDate field.
+func (r *studentResolver) BirthDate(ctx context.Context, obj *model.Student, calendar jalali.Calendar) (*string, error) {
+	if obj.BirthDate.IsZero() {
+		return nil, nil
+	}
+
+	d := calendar.FormatDate(obj.BirthDate)
+
+	return &d, nil
+}
+
+// StudentRegistered is the resolver for the studentRegistered field.
+func (r *subscriptionResolver) StudentRegistered(ctx context.Context, courseID string) (<-chan *model.Student, error) {
+	// checks the existence of the course.
+	_, err := r.Store.Enrolled(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := r.Events.Subscribe(ctx, pubsub.DefaultBuffer)
+	students := make(chan *model.Student)
+
+	go func() {
+		defer close(students)
+
+		for reg := range registrations(ctx, events, courseID, event.StudentRegistered) {
+			s, err := r.Store.Get(ctx, reg.StudentID)
+			if err != nil {
+				log.Printf("registered student %s is not found %s", reg.StudentID, err)
+
+				continue
+			}
 
-	return &enterance, nil
+			s.Courses = nil
+
+			select {
+			case students <- &s:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return students, nil
+}
+
+// CourseSeatsChanged is the resolver for the courseSeatsChanged field.
+func (r *subscriptionResolver) CourseSeatsChanged(ctx context.Context, courseID string) (<-chan *model.CourseSeats, error) {
+	_, err := r.Store.Enrolled(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := r.Events.Subscribe(ctx, pubsub.DefaultBuffer)
+	seats := make(chan *model.CourseSeats)
+
+	go func() {
+		defer close(seats)
+
+		for range registrations(ctx, events, courseID, event.StudentRegistered, event.StudentUnregistered) {
+			enrolled, err := r.Store.Enrolled(ctx, courseID)
+			if err != nil {
+				log.Printf("course %s seats are not available %s", courseID, err)
+
+				continue
+			}
+
+			select {
+			case seats <- &model.CourseSeats{CourseID: courseID, Enrolled: enrolled}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return seats, nil
 }
 
+// Course returns graph.CourseResolver implementation.
+func (r *Resolver) Course() graph.CourseResolver { return &courseResolver{r} }
+
 // Mutation returns graph.MutationResolver implementation.
 func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
 
@@ -105,6 +258,13 @@ func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
 // Student returns graph.StudentResolver implementation.
 func (r *Resolver) Student() graph.StudentResolver { return &studentResolver{r} }
 
-type mutationResolver struct{ *Resolver }
-type queryResolver struct{ *Resolver }
-type studentResolver struct{ *Resolver }
+// Subscription returns graph.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graph.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type (
+	courseResolver       struct{ *Resolver }
+	mutationResolver     struct{ *Resolver }
+	queryResolver        struct{ *Resolver }
+	studentResolver      struct{ *Resolver }
+	subscriptionResolver struct{ *Resolver }
+)
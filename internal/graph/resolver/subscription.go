@@ -0,0 +1,49 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+
+	"github.com/1995parham-teaching/students/internal/event"
+)
+
+// registrations returns the registration payloads of the given event types for the course,
+// the returned channel is closed with the events channel or when the context is canceled.
+func registrations(
+	ctx context.Context, events <-chan event.Event, courseID string, types ...event.Type,
+) <-chan event.Registration {
+	regs := make(chan event.Registration)
+
+	go func() {
+		defer close(regs)
+
+		for e := range events {
+			if !slices.Contains(types, e.Type) {
+				continue
+			}
+
+			var reg event.Registration
+
+			err := json.Unmarshal(e.Payload, &reg)
+			if err != nil {
+				log.Printf("invalid registration event %d %s", e.ID, err)
+
+				continue
+			}
+
+			if reg.CourseID != courseID {
+				continue
+			}
+
+			select {
+			case regs <- reg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return regs
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/1995parham-teaching/students/internal/backup"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/labstack/echo/v4"
+)
+
+type Admin struct {
+	Backup *backup.Scheduler
+}
+
+// CreateBackup takes an online backup of the database.
+func (a Admin) CreateBackup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	r, err := a.Backup.Now(ctx)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusCreated, r)
+}
+
+func (a Admin) Register(g *echo.Group) {
+	g.POST("/backup", a.CreateBackup, policy.Require(policy.CreateBackups, policy.None))
+}
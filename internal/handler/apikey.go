@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store/apikey"
+	"github.com/labstack/echo/v4"
+)
+
+// APIKey manages the api keys of the non-interactive clients, the keys are only returned
+// on creation and rotation.
+type APIKey struct {
+	Store apikey.APIKey
+}
+
+func apiKeyNotFound() error {
+	return problem.New(http.StatusNotFound, problem.CodeAPIKeyNotFound, "api key does not exist")
+}
+
+func (h APIKey) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.APIKeyCreate
+
+	err := c.Bind(&req)
+	if err != nil {
+		return problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return problem.Validation(err)
+	}
+
+	id, key := auth.NewAPIKey()
+
+	k := model.APIKey{
+		ID:         id,
+		Name:       req.Name,
+		Hash:       auth.HashAPIKey(key),
+		Scopes:     req.Scopes,
+		ExpiresAt:  req.ExpiresAt,
+		LastUsedAt: nil,
+		RotatedAt:  nil,
+		CreatedAt:  time.Now(),
+	}
+
+	err = h.Store.Create(ctx, k)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusCreated, response.APIKey{APIKey: k, Key: key})
+}
+
+func (h APIKey) GetAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	ks, err := h.Store.GetAll(ctx)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, ks)
+}
+
+func (h APIKey) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	k, err := h.Store.Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return apiKeyNotFound()
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, k)
+}
+
+// Rotate replaces the key with a new one which has the same id and scopes, the old key stops working immediately.
+func (h APIKey) Rotate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.APIKeyRotate
+
+	err := c.Bind(&req)
+	if err != nil {
+		return problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return problem.Validation(err)
+	}
+
+	k, err := h.Store.Get(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return apiKeyNotFound()
+		}
+
+		return problem.Internal(err)
+	}
+
+	if req.ExpiresAt != nil {
+		k.ExpiresAt = req.ExpiresAt
+	}
+
+	key := auth.RotateAPIKey(k.ID)
+
+	k, err = h.Store.Rotate(ctx, k.ID, auth.HashAPIKey(key), k.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return apiKeyNotFound()
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, response.APIKey{APIKey: k, Key: key})
+}
+
+func (h APIKey) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.Store.Delete(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return apiKeyNotFound()
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h APIKey) Register(g *echo.Group) {
+	manage := policy.Require(policy.ManageAPIKeys, policy.None)
+
+	g.POST("/api-keys", h.Create, manage)
+	g.GET("/api-keys", h.GetAll, manage)
+	g.GET("/api-keys/:id", h.Get, manage)
+	g.POST("/api-keys/:id/rotate", h.Rotate, manage)
+	g.DELETE("/api-keys/:id", h.Delete, manage)
+}
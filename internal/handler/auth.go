@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store/token"
+	"github.com/1995parham-teaching/students/internal/store/user"
+	"github.com/labstack/echo/v4"
+)
+
+var ErrInvalidCredentials = errors.New("username or password is wrong")
+
+type Auth struct {
+	Users   user.User
+	Tokens  auth.Tokens
+	Revoked token.Token
+}
+
+func (a Auth) issue(c echo.Context, u model.User) error {
+	pair, err := a.Tokens.Issue(u)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(http.StatusOK, response.Token{
+		AccessToken:  pair.Access,
+		RefreshToken: pair.Refresh,
+		TokenType:    auth.Scheme,
+		ExpiresIn:    int(pair.ExpiresIn.Seconds()),
+	})
+}
+
+func bindToken(c echo.Context) (request.Token, error) {
+	var req request.Token
+
+	err := c.Bind(&req)
+	if err != nil {
+		return req, problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return req, problem.Validation(err)
+	}
+
+	return req, nil
+}
+
+// Login issues a pair of tokens for the user with the correct password.
+func (a Auth) Login(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.Login
+
+	err := c.Bind(&req)
+	if err != nil {
+		return problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return problem.Validation(err)
+	}
+
+	u, err := a.Users.Get(ctx, req.Username)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return problem.Internal(err)
+	}
+
+	// the unknown users are checked against a dummy hash, so they cannot be told apart by the response time.
+	if !auth.CheckPassword(u.PasswordHash, req.Password) {
+		return problem.New(http.StatusUnauthorized, problem.CodeInvalidCredentials, ErrInvalidCredentials.Error())
+	}
+
+	return a.issue(c, u)
+}
+
+// Refresh exchanges a refresh token for a new pair of tokens, the refresh token is revoked,
+// so each one can be used only once. The role of the user is read again.
+func (a Auth) Refresh(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := bindToken(c)
+	if err != nil {
+		return err
+	}
+
+	claims, err := a.Tokens.Parse(req.Token, auth.TypeRefresh)
+	if err != nil {
+		return problem.New(http.StatusUnauthorized, problem.CodeInvalidToken, auth.ErrInvalidToken.Error())
+	}
+
+	// revoking is the check, so only one of the concurrent refreshes with the same token succeeds.
+	revoked, err := a.Revoked.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	if !revoked {
+		return problem.New(http.StatusUnauthorized, problem.CodeInvalidToken, auth.ErrInvalidToken.Error())
+	}
+
+	u, err := a.Users.Get(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return problem.New(http.StatusUnauthorized, problem.CodeInvalidToken, auth.ErrInvalidToken.Error())
+		}
+
+		return problem.Internal(err)
+	}
+
+	return a.issue(c, u)
+}
+
+// Revoke revokes an access or refresh token, the invalid tokens are ignored as in RFC 7009.
+func (a Auth) Revoke(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := bindToken(c)
+	if err != nil {
+		return err
+	}
+
+	claims, err := a.Tokens.Parse(req.Token, "")
+	if err != nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	_, err = a.Revoked.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Me returns the authenticated principal.
+func (a Auth) Me(c echo.Context) error {
+	p, ok := auth.FromContext(c.Request().Context())
+	if !ok {
+		return problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, auth.ErrUnauthenticated.Error())
+	}
+
+	return c.JSON(http.StatusOK, p)
+}
+
+func (a Auth) Register(g *echo.Group) {
+	g.POST("/auth/login", a.Login)
+	g.POST("/auth/refresh", a.Refresh)
+	g.POST("/auth/revoke", a.Revoke)
+	g.GET("/auth/me", a.Me)
+}
@@ -1,14 +1,15 @@
 package handler
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"log"
-	"math/big"
 	"net/http"
+	"strconv"
 
+	"github.com/1995parham-teaching/students/internal/idgen"
 	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
 	"github.com/1995parham-teaching/students/internal/request"
 	"github.com/1995parham-teaching/students/internal/store/course"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -32,46 +33,78 @@ func (s Course) Create(c echo.Context) error {
 
 	err := c.Bind(&req)
 	if err != nil {
-		log.Println(err)
-
-		return echo.ErrBadRequest
+		return problem.Bind(err)
 	}
 
 	err = req.Validate()
 	if err != nil {
-		log.Println(err)
-
-		return echo.ErrBadRequest
+		return problem.Validation(err)
 	}
 
-	idBig, err := rand.Int(rand.Reader, big.NewInt(CourseIDLen))
-	if err != nil {
-		panic(err)
-	}
-
-	cr := model.Course{
-		Name: req.Name,
-		ID:   fmt.Sprintf("%08d", idBig.Int64()),
-	}
+	cr := req.Course(idgen.New(CourseIDMax))
 
 	err = s.Store.Create(ctx, cr)
 	if err != nil {
 		if errors.Is(err, course.ErrCourseAlreadyExists) {
-			return echo.ErrBadRequest
+			return problem.New(http.StatusBadRequest, problem.CodeCourseExists, "course already exists")
 		}
 
-		return echo.ErrInternalServerError
+		return problem.Internal(err)
 	}
 
 	return c.JSON(http.StatusCreated, cr)
 }
 
+// Import creates courses from a csv file with a name and optional id, capacity and instructor columns.
+func (s Course) Import(c echo.Context) error {
+	return importCSV(c, []string{"name"},
+		func(row csvRow) (model.Course, error) {
+			capacity := 0
+
+			if v := row.Fields["capacity"]; v != "" {
+				c, err := strconv.Atoi(v)
+				if err != nil {
+					return model.Course{}, fmt.Errorf("invalid course capacity %w", err)
+				}
+
+				capacity = c
+			}
+
+			req := request.CourseCreate{
+				Name:       row.Fields["name"],
+				Capacity:   capacity,
+				Instructor: row.Fields["instructor"],
+			}
+
+			err := req.Validate()
+			if err != nil {
+				return model.Course{}, err
+			}
+
+			id := row.Fields["id"]
+			if id == "" {
+				id = idgen.New(CourseIDMax)
+			}
+
+			err = validation.Validate(id, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+			if err != nil {
+				return model.Course{}, fmt.Errorf("invalid course id %w", err)
+			}
+
+			return req.Course(id), nil
+		},
+		func(cr model.Course) string { return cr.ID },
+		s.Store.Create,
+		s.Store.CreateAll,
+	)
+}
+
 func (s Course) GetAll(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	ss, err := s.Store.GetAll(ctx)
 	if err != nil {
-		return echo.ErrInternalServerError
+		return problem.Internal(err)
 	}
 
 	return c.JSON(http.StatusOK, ss)
@@ -84,23 +117,93 @@ func (s Course) Get(c echo.Context) error {
 
 	err := validation.Validate(id, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
 	if err != nil {
-		return echo.ErrBadRequest
+		return problem.Param("id", err)
 	}
 
 	st, err := s.Store.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, course.ErrCourseNotFound) {
-			return echo.ErrNotFound
+			return problem.New(http.StatusNotFound, problem.CodeCourseNotFound, "course does not exist")
 		}
 
-		return echo.ErrInternalServerError
+		return problem.Internal(err)
 	}
 
 	return c.JSON(http.StatusOK, st)
 }
 
+func (s Course) GetMeetings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := c.Param("id")
+
+	err := validation.Validate(id, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+	if err != nil {
+		return problem.Param("id", err)
+	}
+
+	ms, err := s.Store.Meetings(ctx, id)
+	if err != nil {
+		if errors.Is(err, course.ErrCourseNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeCourseNotFound, "course does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, ms)
+}
+
+// SetMeetings replaces the weekly meetings of the course.
+func (s Course) SetMeetings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := c.Param("id")
+
+	err := validation.Validate(id, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+	if err != nil {
+		return problem.Param("id", err)
+	}
+
+	var req request.Meetings
+
+	err = c.Bind(&req)
+	if err != nil {
+		return problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return problem.Validation(err)
+	}
+
+	ms := make([]model.Meeting, 0, len(req))
+	for _, m := range req {
+		ms = append(ms, model.Meeting{
+			Weekday:  m.Weekday,
+			Start:    m.Start,
+			End:      m.End,
+			Location: m.Location,
+		})
+	}
+
+	err = s.Store.SetMeetings(ctx, id, ms)
+	if err != nil {
+		if errors.Is(err, course.ErrCourseNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeCourseNotFound, "course does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, ms)
+}
+
 func (s Course) Register(g *echo.Group) {
-	g.POST("/courses", s.Create)
-	g.GET("/courses", s.GetAll)
-	g.GET("/courses/:id", s.Get)
+	g.POST("/courses", s.Create, policy.Require(policy.WriteCourses, policy.None))
+	g.POST("/courses\\:import", s.Import, policy.Require(policy.WriteCourses, policy.None))
+	g.GET("/courses", s.GetAll, policy.Require(policy.ReadCourses, policy.None))
+	g.GET("/courses/:id", s.Get, policy.Require(policy.ReadCourses, policy.None))
+	g.GET("/courses/:id/meetings", s.GetMeetings, policy.Require(policy.ReadCourses, policy.None))
+	g.PUT("/courses/:id/meetings", s.SetMeetings, policy.Require(policy.WriteCourses, policy.None))
 }
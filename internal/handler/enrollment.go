@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterDeprecation is when the register routes are deprecated in favor of the enrollments,
+// it is sent as the Deprecation header (RFC 9745).
+// nolint: gochecknoglobals
+var RegisterDeprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// Enrollment handles the enrollments of a student, their times are rendered in the Location and in the calendar
+// of the calendar query parameter (gregorian or jalali).
+type Enrollment struct {
+	Store    student.Student
+	Location *time.Location
+}
+
+// enrollmentProblem converts the errors of registering and dropping into problems.
+func enrollmentProblem(err error) error {
+	switch {
+	case errors.Is(err, student.ErrStudentNotFound):
+		return problem.New(http.StatusNotFound, problem.CodeStudentNotFound, "student does not exist")
+	case errors.Is(err, course.ErrCourseNotFound):
+		return problem.New(http.StatusNotFound, problem.CodeCourseNotFound, "course does not exist")
+	case errors.Is(err, course.ErrCourseFull):
+		return problem.New(http.StatusConflict, problem.CodeCourseFull, "course has reached its capacity")
+	case errors.Is(err, student.ErrStudentNotRegistered):
+		return problem.New(http.StatusNotFound, problem.CodeEnrollmentNotFound, "student is not registered in the course")
+	default:
+		return problem.Internal(err)
+	}
+}
+
+// deprecated marks the response of a deprecated route with its successor.
+func deprecated(c echo.Context, successor string) {
+	c.Response().Header().Set("Deprecation", "@"+strconv.FormatInt(RegisterDeprecation.Unix(), 10))
+	c.Response().Header().Set("Link", "<"+successor+`>; rel="successor-version"`)
+}
+
+// params validates the student and optionally the course ids of the path.
+func params(c echo.Context, course bool) (string, string, error) {
+	sid := c.Param("sid")
+	cid := c.Param("cid")
+
+	err := validation.Validate(sid, validation.Length(StudentIDLen, StudentIDLen), is.Digit)
+	if err != nil {
+		return "", "", problem.Param("sid", err)
+	}
+
+	if !course {
+		return sid, "", nil
+	}
+
+	err = validation.Validate(cid, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+	if err != nil {
+		return "", "", problem.Param("cid", err)
+	}
+
+	return sid, cid, nil
+}
+
+// Create enrolls the student, it responds with 201 for a new enrollment and 200 when the student
+// is already enrolled.
+func (h Enrollment) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sid, _, err := params(c, false)
+	if err != nil {
+		return err
+	}
+
+	cal, err := calendar(c)
+	if err != nil {
+		return err
+	}
+
+	var req request.EnrollmentCreate
+
+	err = c.Bind(&req)
+	if err != nil {
+		return problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return problem.Validation(err)
+	}
+
+	e, created, err := h.Store.Enroll(ctx, sid, req.CourseID)
+	if err != nil {
+		return enrollmentProblem(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/v1/students/"+sid+"/enrollments/"+req.CourseID)
+
+	if !created {
+		return c.JSON(http.StatusOK, response.NewEnrollment(e, cal, h.Location))
+	}
+
+	return c.JSON(http.StatusCreated, response.NewEnrollment(e, cal, h.Location))
+}
+
+func (h Enrollment) GetAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sid, _, err := params(c, false)
+	if err != nil {
+		return err
+	}
+
+	cal, err := calendar(c)
+	if err != nil {
+		return err
+	}
+
+	es, err := h.Store.StudentEnrollments(ctx, sid)
+	if err != nil {
+		return enrollmentProblem(err)
+	}
+
+	return c.JSON(http.StatusOK, response.NewEnrollments(es, cal, h.Location))
+}
+
+func (h Enrollment) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sid, cid, err := params(c, true)
+	if err != nil {
+		return err
+	}
+
+	cal, err := calendar(c)
+	if err != nil {
+		return err
+	}
+
+	e, err := h.Store.Enrollment(ctx, sid, cid)
+	if err != nil {
+		return enrollmentProblem(err)
+	}
+
+	return c.JSON(http.StatusOK, response.NewEnrollment(e, cal, h.Location))
+}
+
+// Delete drops the course and responds with the dropped enrollment.
+func (h Enrollment) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sid, cid, err := params(c, true)
+	if err != nil {
+		return err
+	}
+
+	cal, err := calendar(c)
+	if err != nil {
+		return err
+	}
+
+	e, err := h.Store.Drop(ctx, sid, cid)
+	if err != nil {
+		return enrollmentProblem(err)
+	}
+
+	return c.JSON(http.StatusOK, response.NewEnrollment(e, cal, h.Location))
+}
+
+func (h Enrollment) Register(g *echo.Group) {
+	read := policy.Require(policy.ReadStudents, policy.StudentParam("sid"))
+	write := policy.Require(policy.WriteEnrollments, policy.StudentParam("sid"))
+
+	g.POST("/students/:sid/enrollments", h.Create, write)
+	g.GET("/students/:sid/enrollments", h.GetAll, read)
+	g.GET("/students/:sid/enrollments/:cid", h.Get, read)
+	g.DELETE("/students/:sid/enrollments/:cid", h.Delete, write)
+}
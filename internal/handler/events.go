@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/pubsub"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DefaultHeartbeat is the interval of the comments which keep idle streams open through proxies.
+	DefaultHeartbeat = 15 * time.Second
+	// EventsRetry is the reconnection delay which is advised to the clients in milliseconds.
+	EventsRetry = 3000
+)
+
+// Events streams the domain events as server-sent events.
+type Events struct {
+	Broker    *pubsub.Broker
+	Heartbeat time.Duration
+}
+
+// eventsFilter selects the events of a student, a course or the given types, empty fields match all.
+type eventsFilter struct {
+	studentID string
+	courseID  string
+	types     []string
+}
+
+func (f eventsFilter) match(e event.Event) bool {
+	if len(f.types) != 0 && !slices.Contains(f.types, string(e.Type)) {
+		return false
+	}
+
+	sid, cid := e.Subjects()
+
+	if f.studentID != "" && f.studentID != sid {
+		return false
+	}
+
+	if f.courseID != "" && f.courseID != cid {
+		return false
+	}
+
+	return true
+}
+
+func (h Events) filter(c echo.Context) (eventsFilter, error) {
+	f := eventsFilter{
+		studentID: c.QueryParam("student_id"),
+		courseID:  c.QueryParam("course_id"),
+		types:     c.QueryParams()["type"],
+	}
+
+	if f.studentID != "" {
+		err := validation.Validate(f.studentID, validation.Length(StudentIDLen, StudentIDLen), is.Digit)
+		if err != nil {
+			return f, problem.Param("student_id", err)
+		}
+	}
+
+	if f.courseID != "" {
+		err := validation.Validate(f.courseID, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+		if err != nil {
+			return f, problem.Param("course_id", err)
+		}
+	}
+
+	for _, t := range f.types {
+		if !slices.Contains(event.Types(), event.Type(t)) {
+			return f, problem.Param("type", nil)
+		}
+	}
+
+	return f, nil
+}
+
+// lastEventID reads the Last-Event-ID header which is sent by the browsers on reconnection,
+// or the last_event_id query parameter for the first connection.
+func lastEventID(c echo.Context) (uint64, bool, error) {
+	v := c.Request().Header.Get("Last-Event-ID")
+	if v == "" {
+		v = c.QueryParam("last_event_id")
+	}
+
+	if v == "" {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, problem.Param("last_event_id", err)
+	}
+
+	return id, true, nil
+}
+
+// Stream sends the events until the client disconnects. Slow clients are disconnected by
+// the broker and they can resume the stream using their last event id.
+func (h Events) Stream(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	f, err := h.filter(c)
+	if err != nil {
+		return err
+	}
+
+	lastID, resume, err := lastEventID(c)
+	if err != nil {
+		return err
+	}
+
+	var events <-chan event.Event
+	if resume {
+		events = h.Broker.Resume(ctx, pubsub.DefaultBuffer, lastID)
+	} else {
+		events = h.Broker.Subscribe(ctx, pubsub.DefaultBuffer)
+	}
+
+	resp := c.Response()
+
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	// disables the response buffering of nginx.
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+
+	_, err = fmt.Fprintf(resp, "retry: %d\n\n", EventsRetry)
+	if err != nil {
+		return nil
+	}
+
+	resp.Flush()
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			_, err := fmt.Fprint(resp, ": heartbeat\n\n")
+			if err != nil {
+				return nil
+			}
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if !f.match(e) {
+				continue
+			}
+
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Println(err)
+
+				continue
+			}
+
+			_, err = fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+			if err != nil {
+				return nil
+			}
+		}
+
+		resp.Flush()
+	}
+}
+
+func (h Events) Register(g *echo.Group) {
+	g.GET("/events", h.Stream, policy.Require(policy.ReadEvents, policy.None))
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/1995parham-teaching/students/internal/export"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v4"
+)
+
+// exportFlushEvery is the number of rows which are written before flushing the response.
+const exportFlushEvery = 100
+
+// Export streams course rosters and enrollments as csv, ndjson or xlsx,
+// the format is selected by the format query parameter or the accept header.
+type Export struct {
+	Store   student.Student
+	Courses course.Course
+}
+
+// begin negotiates the export format and returns a writer over the response,
+// the response is committed by the first write.
+func begin(c echo.Context, name string, columns []string) (export.Writer, error) {
+	f, err := export.Negotiate(c.QueryParam("format"), c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return nil, problem.New(http.StatusNotAcceptable, problem.CodeNotAcceptable, err.Error())
+	}
+
+	h := c.Response().Header()
+	h.Set(echo.HeaderContentType, f.ContentType())
+	h.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name+f.Extension()))
+
+	w, err := export.NewWriter(f, c.Response(), columns)
+	if err != nil {
+		return nil, problem.Internal(err)
+	}
+
+	return w, nil
+}
+
+// row writes a row and flushes the response every exportFlushEvery rows.
+func row(c echo.Context, w export.Writer, n *int, values ...string) error {
+	err := w.Write(values)
+	if err != nil {
+		return err
+	}
+
+	*n++
+	if *n%exportFlushEvery == 0 {
+		c.Response().Flush()
+	}
+
+	return nil
+}
+
+func (e Export) Roster(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := c.Param("id")
+
+	err := validation.Validate(id, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+	if err != nil {
+		return problem.Param("id", err)
+	}
+
+	// only the instructor of the course can see its roster.
+	cr, err := e.Courses.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, course.ErrCourseNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeCourseNotFound, "course does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	err = policy.Check(ctx, policy.ReadRosters, policy.Course(cr.Instructor))
+	if err != nil {
+		return policy.Problem(err)
+	}
+
+	var w export.Writer
+
+	n := 0
+
+	err = e.Store.Roster(ctx, id, func(st model.Student) error {
+		// the writer is created on the first row, so not found errors can still change the status code.
+		if w == nil {
+			var err error
+
+			w, err = begin(c, "roster-"+id, []string{"student_id", "student_name"})
+			if err != nil {
+				return err
+			}
+		}
+
+		return row(c, w, &n, st.ID, st.Name)
+	})
+	if err != nil {
+		if errors.Is(err, course.ErrCourseNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeCourseNotFound, "course does not exist")
+		}
+
+		var p *problem.Problem
+		if errors.As(err, &p) {
+			return p
+		}
+
+		log.Println(err)
+
+		if c.Response().Committed {
+			return nil
+		}
+
+		return problem.Internal(err)
+	}
+
+	// empty roster
+	if w == nil {
+		w, err = begin(c, "roster-"+id, []string{"student_id", "student_name"})
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+func (e Export) Enrollments(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	w, err := begin(c, "enrollments", []string{"student_id", "student_name", "course_id", "course_name"})
+	if err != nil {
+		return err
+	}
+
+	n := 0
+
+	err = e.Store.Enrollments(ctx, func(en model.Enrollment) error {
+		return row(c, w, &n, en.StudentID, en.StudentName, en.CourseID, en.CourseName)
+	})
+	if err != nil {
+		log.Println(err)
+
+		if c.Response().Committed {
+			return nil
+		}
+
+		return problem.Internal(err)
+	}
+
+	return w.Close()
+}
+
+func (e Export) Register(g *echo.Group) {
+	g.GET("/courses/:id/students", e.Roster)
+	g.GET("/export/enrollments", e.Enrollments, policy.Require(policy.ExportEnrollments, policy.None))
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ImportFileField is the multipart field which contains the csv file,
+	// the csv file can also be sent directly as the request body.
+	ImportFileField = "file"
+	// ImportMaxSize is the maximum size of an uploaded csv file with its multipart form.
+	ImportMaxSize = 8 << 20
+)
+
+var (
+	ErrMissingColumn = errors.New("csv header does not have a required column")
+	ErrFieldCount    = errors.New("wrong number of fields")
+	ErrRowInternal   = errors.New("internal error")
+)
+
+// rowErrors are the store errors which are reported on the import rows, the other errors
+// may expose the database so they are logged and the row only reports ErrRowInternal.
+// nolint: gochecknoglobals
+var rowErrors = []error{
+	student.ErrStudentAlreadyExists,
+	student.ErrNationalIDTaken,
+	course.ErrCourseAlreadyExists,
+}
+
+// storeError returns the message of a row which is failed on the store in the given language.
+func storeError(lang i18n.Lang, err error) string {
+	for _, e := range rowErrors {
+		if errors.Is(err, e) {
+			return i18n.Error(lang, e)
+		}
+	}
+
+	log.Println(err)
+
+	return i18n.Error(lang, ErrRowInternal)
+}
+
+type csvRow struct {
+	Line   int
+	Fields map[string]string
+	Err    error
+}
+
+// upload returns the uploaded file either from a multipart form or directly from the request body,
+// the request body is limited to the given size so the large uploads are not read into memory or disk.
+func upload(c echo.Context, limit int64) (io.ReadCloser, error) {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, limit)
+
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return c.Request().Body, nil
+	}
+
+	fh, err := c.FormFile(ImportFileField)
+	if err != nil {
+		return nil, err
+	}
+
+	return fh.Open()
+}
+
+// uploadProblem returns the problem of an upload which cannot be read, the uploads which are larger
+// than their limit are rejected with 413.
+func uploadProblem(err error, detail string) error {
+	var me *http.MaxBytesError
+	if errors.As(err, &me) {
+		p := problem.New(http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge, detail)
+		p.Err = err
+
+		return p
+	}
+
+	return problem.Bind(err)
+}
+
+// readCSV reads the csv file from request, the first record is the header
+// and each row is returned as map from the (lowercase) column name to its value.
+func readCSV(c echo.Context, required ...string) ([]csvRow, error) {
+	body, err := upload(c, ImportMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	r := csv.NewReader(body)
+	r.TrimLeadingSpace = true
+	// rows with wrong number of fields are reported instead of rejecting the whole file.
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read csv header %w", err)
+	}
+
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	for _, col := range required {
+		found := false
+
+		for _, h := range header {
+			if h == col {
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, col)
+		}
+	}
+
+	rows := make([]csvRow, 0)
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+
+		if len(record) != len(header) {
+			rows = append(rows, csvRow{
+				Line:   line,
+				Fields: nil,
+				Err:    fmt.Errorf("%w: expected %d, got %d", ErrFieldCount, len(header), len(record)),
+			})
+
+			continue
+		}
+
+		fields := make(map[string]string, len(header))
+		for i, h := range header {
+			fields[h] = strings.TrimSpace(record[i])
+		}
+
+		rows = append(rows, csvRow{
+			Line:   line,
+			Fields: fields,
+			Err:    nil,
+		})
+	}
+
+	return rows, nil
+}
+
+// importCSV runs a bulk import over the uploaded csv file. parse validates each row and converts it into
+// its model. In the atomic mode (default) all rows are created in a single transaction and any failure
+// rejects the whole file, otherwise valid rows are created one by one and failures are only reported.
+// The dry_run query parameter only validates the file and the row errors are in the language of the request.
+func importCSV[T any](
+	c echo.Context,
+	columns []string,
+	parse func(csvRow) (T, error),
+	id func(T) string,
+	create func(context.Context, T) error,
+	createAll func(context.Context, []T) error,
+) error {
+	ctx := c.Request().Context()
+	lang := i18n.FromContext(ctx)
+
+	dryRun := false
+	atomic := true
+
+	err := echo.QueryParamsBinder(c).Bool("dry_run", &dryRun).Bool("atomic", &atomic).BindError()
+	if err != nil {
+		var be *echo.BindingError
+		if errors.As(err, &be) {
+			return problem.Param(be.Field, err)
+		}
+
+		return problem.Bind(err)
+	}
+
+	rows, err := readCSV(c, columns...)
+	if err != nil {
+		return uploadProblem(err, "file is too large")
+	}
+
+	report := response.Import{
+		DryRun:  dryRun,
+		Atomic:  atomic,
+		Total:   len(rows),
+		Created: 0,
+		Failed:  0,
+		Rows:    make([]response.ImportRow, len(rows)),
+	}
+
+	items := make([]T, 0, len(rows))
+	// indices maps each valid item to its row in the report.
+	indices := make([]int, 0, len(rows))
+
+	for i, row := range rows {
+		report.Rows[i].Line = row.Line
+
+		if row.Err != nil {
+			report.Rows[i].Error = i18n.Error(lang, row.Err)
+			report.Failed++
+
+			continue
+		}
+
+		item, err := parse(row)
+		if err != nil {
+			report.Rows[i].Error = i18n.Error(lang, err)
+			report.Failed++
+
+			continue
+		}
+
+		items = append(items, item)
+		indices = append(indices, i)
+	}
+
+	if dryRun || (atomic && report.Failed > 0) {
+		if report.Failed > 0 {
+			return c.JSON(http.StatusUnprocessableEntity, report)
+		}
+
+		return c.JSON(http.StatusOK, report)
+	}
+
+	if atomic {
+		err := createAll(ctx, items)
+		if err != nil {
+			var be store.BatchError
+			if !errors.As(err, &be) {
+				return problem.Internal(err)
+			}
+
+			report.Rows[indices[be.Index]].Error = storeError(lang, be.Err)
+			report.Failed++
+
+			return c.JSON(http.StatusUnprocessableEntity, report)
+		}
+
+		for i, item := range items {
+			report.Rows[indices[i]].ID = id(item)
+		}
+
+		report.Created = len(items)
+
+		return c.JSON(http.StatusCreated, report)
+	}
+
+	for i, item := range items {
+		err := create(ctx, item)
+		if err != nil {
+			report.Rows[indices[i]].Error = storeError(lang, err)
+			report.Failed++
+
+			continue
+		}
+
+		report.Rows[indices[i]].ID = id(item)
+		report.Created++
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,199 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/handler"
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/labstack/echo/v4"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{ //nolint:exhaustruct
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	// each connection has its own in-memory database.
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+
+	return db
+}
+
+// setupApp serves the handlers which are registered by register to an admin.
+func setupApp(register func(g *echo.Group)) *echo.Echo {
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(i18n.Middleware())
+	app.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := auth.WithPrincipal(c.Request().Context(), auth.Principal{
+				Username:  "root",
+				Role:      model.RoleAdmin,
+				StudentID: "",
+				KeyID:     "",
+				Scopes:    nil,
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	})
+
+	register(app.Group("/v1"))
+
+	return app
+}
+
+func TestStudent_Import(t *testing.T) {
+	t.Parallel()
+
+	// the second row has an invalid name and the third one is a duplicate of the first one.
+	const file = "id,name\n90101010,Ali Rezaei\n90101011,R2D2\n90101010,Reza Alavi\n"
+
+	cases := []struct {
+		name    string
+		query   string
+		status  int
+		created []string
+		errors  []int
+	}{
+		{"dry run", "?dry_run=true&atomic=false", http.StatusUnprocessableEntity, nil, []int{3}},
+		{"atomic", "", http.StatusUnprocessableEntity, nil, []int{3}},
+		{"not atomic", "?atomic=false", http.StatusOK, []string{"90101010"}, []int{3, 4}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := student.NewSQL(setupTestDB(t))
+			app := setupApp(handler.Student{Store: store, Location: time.UTC}.Register)
+
+			r := httptest.NewRequest(http.MethodPost, "/v1/students:import"+tc.query, strings.NewReader(file))
+			r.Header.Set(echo.HeaderContentType, "text/csv")
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, w.Code, w.Body)
+			}
+
+			var report response.Import
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatal(err)
+			}
+
+			errors := make([]int, 0)
+
+			for _, row := range report.Rows {
+				if row.Error != "" {
+					errors = append(errors, row.Line)
+				}
+
+				// the store errors are reported instead of being hidden as internal errors.
+				if row.Error == handler.ErrRowInternal.Error() {
+					t.Errorf("expected the row error of line %d to be reported", row.Line)
+				}
+			}
+
+			if report.Total != 3 || report.Created != len(tc.created) || !slices.Equal(errors, tc.errors) {
+				t.Errorf("expected %d created and errors on %v, got %+v", len(tc.created), tc.errors, report)
+			}
+
+			// the dry run and the rejected atomic import don't create any student.
+			ss, err := store.GetAll(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(ss) != len(tc.created) {
+				t.Errorf("expected %d students in the store, got %d", len(tc.created), len(ss))
+			}
+		})
+	}
+}
+
+func TestStudent_Import_Rollback(t *testing.T) {
+	t.Parallel()
+
+	store := student.NewSQL(setupTestDB(t))
+	app := setupApp(handler.Student{Store: store, Location: time.UTC}.Register)
+
+	// all the rows are valid but the last one conflicts with the first one in the store.
+	r := httptest.NewRequest(http.MethodPost, "/v1/students:import",
+		strings.NewReader("id,name\n90101010,Ali Rezaei\n90101011,Reza Alavi\n90101010,Sara Karimi\n"))
+	r.Header.Set(echo.HeaderContentType, "text/csv")
+	r.Header.Set("Accept-Language", "fa")
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d: %s", w.Code, w.Body)
+	}
+
+	var report response.Import
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	// the conflict is reported on its row in the language of the request.
+	if report.Rows[2].Error != i18n.Error(i18n.Persian, student.ErrStudentAlreadyExists) {
+		t.Errorf("expected the conflict on the last row, got %+v", report.Rows)
+	}
+
+	ss, err := store.GetAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ss) != 0 {
+		t.Errorf("expected the atomic import to be rolled back, got %d students", len(ss))
+	}
+}
+
+func TestStudent_Import_TooLarge(t *testing.T) {
+	t.Parallel()
+
+	app := setupApp(handler.Student{Store: student.NewSQL(setupTestDB(t)), Location: time.UTC}.Register)
+
+	body := bytes.Repeat([]byte("90101010,Ali Rezaei\n"), handler.ImportMaxSize/20+1)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/students:import", bytes.NewReader(append([]byte("id,name\n"), body...)))
+	r.Header.Set(echo.HeaderContentType, "text/csv")
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,568 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/idgen"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/oneroster"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/sourcedid"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// OneRosterMaxSize is the maximum size of an uploaded bundle with its multipart form.
+	OneRosterMaxSize = 32 << 20
+
+	OneRosterOrgID = "org-university"
+	OneRosterTerm  = "academic-year"
+
+	// sourced ids of entities which are not imported from another system,
+	// e.g. student-12345678.
+	studentSourcedPrefix    = "student-"
+	courseSourcedPrefix     = "course-"
+	enrollmentSourcedPrefix = "enrollment-"
+)
+
+// OneRoster imports and exports students, courses and their enrollments as OneRoster 1.1 bundles.
+// Sourced ids of imported entities are stored, so exports use the same sourced ids as the LMS.
+// The imported students and the academic year of the exports are in the Location of the tenant.
+type OneRoster struct {
+	Students   student.Student
+	Courses    course.Course
+	SourcedIDs sourcedid.SourcedID
+	University string
+	Location   *time.Location
+}
+
+func (o OneRoster) Export(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	b, err := o.bundle(ctx)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	var buf bytes.Buffer
+
+	err = oneroster.Write(&buf, b)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="oneroster.zip"`)
+
+	return c.Blob(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// bundle collects all students, courses and enrollments with their sourced ids.
+func (o OneRoster) bundle(ctx context.Context) (oneroster.Bundle, error) {
+	start, end := academicYear(time.Now().In(o.Location))
+
+	b := oneroster.Bundle{
+		SystemName:  "students",
+		OrgID:       OneRosterOrgID,
+		OrgName:     o.University,
+		TermID:      OneRosterTerm,
+		TermTitle:   start[:4] + "-" + end[:4],
+		TermStart:   start,
+		TermEnd:     end,
+		Users:       nil,
+		Classes:     nil,
+		Enrollments: nil,
+	}
+
+	classIDs, err := o.sourcedIDs(ctx, sourcedid.KindClass)
+	if err != nil {
+		return b, err
+	}
+
+	userIDs, err := o.sourcedIDs(ctx, sourcedid.KindUser)
+	if err != nil {
+		return b, err
+	}
+
+	enrollmentIDs, err := o.sourcedIDs(ctx, sourcedid.KindEnrollment)
+	if err != nil {
+		return b, err
+	}
+
+	courses, err := o.Courses.GetAll(ctx)
+	if err != nil {
+		return b, err
+	}
+
+	for _, cr := range courses {
+		id := classIDs(cr.ID, courseSourcedPrefix+cr.ID)
+
+		b.Classes = append(b.Classes, oneroster.Class{
+			Line:      0,
+			SourcedID: id,
+			Status:    oneroster.StatusActive,
+			Title:     cr.Name,
+			ClassCode: cr.ID,
+			CourseID:  id,
+			SchoolID:  OneRosterOrgID,
+			TermIDs:   OneRosterTerm,
+		})
+	}
+
+	students, err := o.Students.GetAll(ctx)
+	if err != nil {
+		return b, err
+	}
+
+	for _, st := range students {
+		given, family, _ := strings.Cut(st.Name, " ")
+
+		b.Users = append(b.Users, oneroster.User{
+			Line:       0,
+			SourcedID:  userIDs(st.ID, studentSourcedPrefix+st.ID),
+			Status:     oneroster.StatusActive,
+			Role:       oneroster.RoleStudent,
+			Username:   st.ID,
+			GivenName:  given,
+			FamilyName: family,
+			Identifier: st.ID,
+			Enabled:    true,
+		})
+	}
+
+	err = o.Students.Enrollments(ctx, func(e model.Enrollment) error {
+		b.Enrollments = append(b.Enrollments, oneroster.Enrollment{
+			Line: 0,
+			SourcedID: enrollmentIDs(
+				enrollmentLocalID(e.CourseID, e.StudentID),
+				enrollmentSourcedPrefix+e.CourseID+"-"+e.StudentID,
+			),
+			Status:   oneroster.StatusActive,
+			ClassID:  classIDs(e.CourseID, courseSourcedPrefix+e.CourseID),
+			SchoolID: OneRosterOrgID,
+			UserID:   userIDs(e.StudentID, studentSourcedPrefix+e.StudentID),
+			Role:     oneroster.RoleStudent,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return b, err
+	}
+
+	return b, nil
+}
+
+// sourcedIDs returns a lookup function from local identifiers to their stored sourced id or the given default.
+func (o OneRoster) sourcedIDs(ctx context.Context, kind sourcedid.Kind) (func(id string, def string) string, error) {
+	ids, err := o.SourcedIDs.All(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(id string, def string) string {
+		if sid, ok := ids[id]; ok {
+			return sid
+		}
+
+		return def
+	}, nil
+}
+
+func enrollmentLocalID(cid string, sid string) string {
+	return cid + "/" + sid
+}
+
+// academicYear returns the start and end date of the academic year which contains t,
+// academic years start at September.
+func academicYear(t time.Time) (string, string) {
+	year := t.Year()
+	if t.Month() < time.September {
+		year--
+	}
+
+	return fmt.Sprintf("%04d-09-01", year), fmt.Sprintf("%04d-08-31", year+1)
+}
+
+// nolint: cyclop, funlen
+func (o OneRoster) Import(c echo.Context) error {
+	ctx := c.Request().Context()
+	lang := i18n.FromContext(ctx)
+
+	data, err := readBundle(c)
+	if err != nil {
+		return err
+	}
+
+	b, err := oneroster.Read(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return problem.Bind(err)
+	}
+
+	report := response.OneRoster{
+		Users:       response.OneRosterCount{Created: 0, Matched: 0, Deleted: 0, Skipped: 0},
+		Classes:     response.OneRosterCount{Created: 0, Matched: 0, Deleted: 0, Skipped: 0},
+		Enrollments: response.OneRosterCount{Created: 0, Matched: 0, Deleted: 0, Skipped: 0},
+		Errors:      make([]string, 0),
+	}
+
+	fail := func(file string, line int, err error) {
+		report.Errors = append(report.Errors, fmt.Sprintf("%s:%d: %s", file, line, rosterError(lang, err)))
+	}
+
+	// users and classes map sourced ids of this bundle into local identifiers.
+	users := make(map[string]string, len(b.Users))
+	classes := make(map[string]string, len(b.Classes))
+
+	for _, u := range b.Users {
+		if u.Role != oneroster.RoleStudent || u.Status == oneroster.StatusToBeDeleted {
+			report.Users.Skipped++
+
+			continue
+		}
+
+		id, created, err := o.user(ctx, u)
+		if err != nil {
+			fail(oneroster.FileUsers, u.Line, err)
+
+			continue
+		}
+
+		users[u.SourcedID] = id
+
+		if created {
+			report.Users.Created++
+		} else {
+			report.Users.Matched++
+		}
+	}
+
+	for _, cl := range b.Classes {
+		if cl.Status == oneroster.StatusToBeDeleted {
+			report.Classes.Skipped++
+
+			continue
+		}
+
+		id, created, err := o.class(ctx, cl)
+		if err != nil {
+			fail(oneroster.FileClasses, cl.Line, err)
+
+			continue
+		}
+
+		classes[cl.SourcedID] = id
+
+		if created {
+			report.Classes.Created++
+		} else {
+			report.Classes.Matched++
+		}
+	}
+
+	for _, e := range b.Enrollments {
+		if e.Role != oneroster.RoleStudent {
+			report.Enrollments.Skipped++
+
+			continue
+		}
+
+		deleted := e.Status == oneroster.StatusToBeDeleted
+
+		sid, err := o.local(ctx, users, sourcedid.KindUser, e.UserID, studentSourcedPrefix, o.studentExists(ctx))
+		if err != nil {
+			// the enrollments of the unknown students are already deleted.
+			if deleted && errors.Is(err, sourcedid.ErrSourcedIDNotFound) {
+				report.Enrollments.Skipped++
+
+				continue
+			}
+
+			fail(oneroster.FileEnrollments, e.Line, fmt.Errorf("user %s %w", e.UserID, err))
+
+			continue
+		}
+
+		cid, err := o.local(ctx, classes, sourcedid.KindClass, e.ClassID, courseSourcedPrefix, o.courseExists(ctx))
+		if err != nil {
+			if deleted && errors.Is(err, sourcedid.ErrSourcedIDNotFound) {
+				report.Enrollments.Skipped++
+
+				continue
+			}
+
+			fail(oneroster.FileEnrollments, e.Line, fmt.Errorf("class %s %w", e.ClassID, err))
+
+			continue
+		}
+
+		if deleted {
+			err := o.Students.Unregister(ctx, sid, cid)
+			if errors.Is(err, student.ErrStudentNotRegistered) {
+				report.Enrollments.Skipped++
+
+				continue
+			}
+
+			if err != nil {
+				fail(oneroster.FileEnrollments, e.Line, err)
+
+				continue
+			}
+
+			report.Enrollments.Deleted++
+
+			continue
+		}
+
+		created, err := o.enroll(ctx, e.SourcedID, sid, cid)
+		if err != nil {
+			fail(oneroster.FileEnrollments, e.Line, err)
+
+			continue
+		}
+
+		if !created {
+			report.Enrollments.Matched++
+
+			continue
+		}
+
+		report.Enrollments.Created++
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// local finds the local identifier of a sourced id, first from the entities of the current bundle.
+func (o OneRoster) local(
+	ctx context.Context, bundle map[string]string, kind sourcedid.Kind, sourcedID string, prefix string,
+	exists func(string) error,
+) (string, error) {
+	if id, ok := bundle[sourcedID]; ok {
+		return id, nil
+	}
+
+	return o.resolve(ctx, kind, sourcedID, prefix, exists)
+}
+
+// enroll registers the student into the course when the student is not already registered.
+func (o OneRoster) enroll(ctx context.Context, sourcedID string, sid string, cid string) (bool, error) {
+	st, err := o.Students.Get(ctx, sid)
+	if err != nil {
+		return false, err
+	}
+
+	registered := false
+
+	for _, cr := range st.Courses {
+		if cr.ID == cid {
+			registered = true
+		}
+	}
+
+	if !registered {
+		err := o.Students.Register(ctx, sid, cid)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !strings.HasPrefix(sourcedID, enrollmentSourcedPrefix) {
+		err := o.SourcedIDs.Put(ctx, sourcedid.KindEnrollment, sourcedID, enrollmentLocalID(cid, sid))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return !registered, nil
+}
+
+func (o OneRoster) studentExists(ctx context.Context) func(string) error {
+	return func(id string) error {
+		_, err := o.Students.Get(ctx, id)
+
+		return err
+	}
+}
+
+func (o OneRoster) courseExists(ctx context.Context) func(string) error {
+	return func(id string) error {
+		_, err := o.Courses.Get(ctx, id)
+
+		return err
+	}
+}
+
+// user returns the local identifier of the given user and creates the student when it does not exist.
+func (o OneRoster) user(ctx context.Context, u oneroster.User) (string, bool, error) {
+	id, err := o.resolve(ctx, sourcedid.KindUser, u.SourcedID, studentSourcedPrefix, o.studentExists(ctx))
+	if err == nil {
+		return id, false, nil
+	}
+
+	if !errors.Is(err, sourcedid.ErrSourcedIDNotFound) {
+		return "", false, err
+	}
+
+	// nolint: exhaustruct
+	req := request.StudentCreate{
+		Name: strings.TrimSpace(u.GivenName + " " + u.FamilyName),
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return "", false, err
+	}
+
+	st := req.Student(idgen.New(StudentIDMax), time.Now().In(o.Location))
+
+	err = o.Students.Create(ctx, st)
+	if err != nil {
+		return "", false, err
+	}
+
+	err = o.SourcedIDs.Put(ctx, sourcedid.KindUser, u.SourcedID, st.ID)
+	if err != nil {
+		return "", false, err
+	}
+
+	return st.ID, true, nil
+}
+
+// class returns the local identifier of the given class and creates the course when it does not exist.
+func (o OneRoster) class(ctx context.Context, cl oneroster.Class) (string, bool, error) {
+	id, err := o.resolve(ctx, sourcedid.KindClass, cl.SourcedID, courseSourcedPrefix, o.courseExists(ctx))
+	if err == nil {
+		return id, false, nil
+	}
+
+	if !errors.Is(err, sourcedid.ErrSourcedIDNotFound) {
+		return "", false, err
+	}
+
+	req := request.CourseCreate{
+		Name:       cl.Title,
+		Capacity:   0,
+		Instructor: "",
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return "", false, err
+	}
+
+	cr := req.Course(idgen.New(CourseIDMax))
+
+	err = o.Courses.Create(ctx, cr)
+	if err != nil {
+		return "", false, err
+	}
+
+	err = o.SourcedIDs.Put(ctx, sourcedid.KindClass, cl.SourcedID, cr.ID)
+	if err != nil {
+		return "", false, err
+	}
+
+	return cr.ID, true, nil
+}
+
+// resolve finds the local identifier of a sourced id, either from the stored sourced ids
+// or from the sourced ids which are generated on export (e.g. student-12345678).
+// exists is used to make sure the local entity is still there.
+func (o OneRoster) resolve(
+	ctx context.Context, kind sourcedid.Kind, sourcedID string, prefix string, exists func(string) error,
+) (string, error) {
+	id, err := o.SourcedIDs.Local(ctx, kind, sourcedID)
+	if err != nil {
+		if !errors.Is(err, sourcedid.ErrSourcedIDNotFound) {
+			return "", err
+		}
+
+		local, ok := strings.CutPrefix(sourcedID, prefix)
+		if !ok {
+			return "", err
+		}
+
+		id = local
+	}
+
+	err = exists(id)
+	if err != nil {
+		if errors.Is(err, student.ErrStudentNotFound) || errors.Is(err, course.ErrCourseNotFound) {
+			return "", sourcedid.ErrSourcedIDNotFound
+		}
+
+		return "", err
+	}
+
+	return id, nil
+}
+
+// rosterErrors are the errors which are reported on the rows of the bundle in addition to the rowErrors.
+// nolint: gochecknoglobals
+var rosterErrors = []error{
+	sourcedid.ErrSourcedIDNotFound,
+	student.ErrStudentNotFound,
+	course.ErrCourseNotFound,
+	course.ErrCourseFull,
+}
+
+// rosterError returns the message of a row which is failed in the given language, the validation errors
+// and the known errors are reported and the others are logged and reported as ErrRowInternal.
+func rosterError(lang i18n.Lang, err error) string {
+	var (
+		errs validation.Errors
+		e    validation.Error
+	)
+
+	if errors.As(err, &errs) || errors.As(err, &e) {
+		return i18n.Error(lang, err)
+	}
+
+	for _, known := range slices.Concat(rowErrors, rosterErrors) {
+		if errors.Is(err, known) {
+			return i18n.Error(lang, err)
+		}
+	}
+
+	log.Println(err)
+
+	return i18n.Error(lang, ErrRowInternal)
+}
+
+// readBundle reads the uploaded zip bundle into memory, because zip files can only be read with random access.
+func readBundle(c echo.Context) ([]byte, error) {
+	body, err := upload(c, OneRosterMaxSize)
+	if err != nil {
+		return nil, uploadProblem(err, "bundle is too large")
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, uploadProblem(err, "bundle is too large")
+	}
+
+	return data, nil
+}
+
+func (o OneRoster) Register(g *echo.Group) {
+	g.GET("/oneroster/export", o.Export, policy.Require(policy.SyncOneRoster, policy.None))
+	g.POST("/oneroster/import", o.Import, policy.Require(policy.SyncOneRoster, policy.None))
+}
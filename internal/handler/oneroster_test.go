@@ -0,0 +1,244 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/handler"
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/oneroster"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/sourcedid"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/labstack/echo/v4"
+)
+
+// bulk writes the bundle as a bulk zip file.
+func bulk(t *testing.T, b oneroster.Bundle) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := oneroster.Write(&buf, b); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// delta writes a zip file with the given enrollments, the bulk files don't have the status of the rows.
+func delta(t *testing.T, enrollments string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	files := map[string]string{
+		oneroster.FileUsers:       "sourcedId,role,givenName,familyName\n",
+		oneroster.FileClasses:     "sourcedId,title\n",
+		oneroster.FileEnrollments: "sourcedId,status,classSourcedId,userSourcedId,role\n" + enrollments,
+	}
+
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// importBundle imports the zip file and returns its report.
+func importBundle(t *testing.T, app *echo.Echo, data []byte, lang string) response.OneRoster {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/oneroster/import", bytes.NewReader(data))
+	r.Header.Set(echo.HeaderContentType, "application/zip")
+	r.Header.Set("Accept-Language", lang)
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+
+	var report response.OneRoster
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	return report
+}
+
+// exportBundle exports the bundle and reads it back.
+func exportBundle(t *testing.T, app *echo.Echo) oneroster.Bundle {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/oneroster/export", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+
+	b, err := oneroster.Read(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatalf("failed to read the exported bundle: %v", err)
+	}
+
+	return b
+}
+
+// sourcedIDs returns the sorted sourced ids of the bundle as users, classes and enrollments.
+func sourcedIDs(b oneroster.Bundle) string {
+	var ids []string
+
+	for _, u := range b.Users {
+		ids = append(ids, "user "+u.SourcedID)
+	}
+
+	for _, cl := range b.Classes {
+		ids = append(ids, "class "+cl.SourcedID)
+	}
+
+	for _, e := range b.Enrollments {
+		ids = append(ids, "enrollment "+e.SourcedID)
+	}
+
+	slices.Sort(ids)
+
+	return strings.Join(ids, ", ")
+}
+
+func TestOneRoster_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := setupTestDB(t)
+
+	ss := student.NewSQL(db)
+	cs := course.NewSQL(db)
+
+	// a student which is created here is exported with its generated sourced id.
+	err := cs.Create(ctx, model.Course{ID: "00000001", Name: "Internet Engineering"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Create(ctx, model.Student{ID: "90101010", Name: "Parham Alvani", Courses: nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.Register(ctx, "90101010", "00000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := setupApp(handler.OneRoster{
+		Students:   ss,
+		Courses:    cs,
+		SourcedIDs: sourcedid.NewSQL(db),
+		University: "Amirkabir University of Technology",
+		Location:   time.UTC,
+	}.Register)
+
+	lms := oneroster.Bundle{
+		SystemName: "moodle",
+		OrgID:      "org",
+		OrgName:    "Amirkabir University of Technology",
+		TermID:     "term",
+		TermTitle:  "2026-2027",
+		TermStart:  "2026-09-01",
+		TermEnd:    "2027-08-31",
+		Users: []oneroster.User{{
+			Line: 0, SourcedID: "moodle-u-1", Status: oneroster.StatusActive, Role: oneroster.RoleStudent,
+			Username: "elahe", GivenName: "Elahe", FamilyName: "Dastan", Identifier: "", Enabled: true,
+		}},
+		Classes: []oneroster.Class{{
+			Line: 0, SourcedID: "moodle-c-1", Status: oneroster.StatusActive, Title: "Compiler Design",
+			ClassCode: "", CourseID: "moodle-c-1", SchoolID: "org", TermIDs: "term",
+		}},
+		Enrollments: []oneroster.Enrollment{{
+			Line: 0, SourcedID: "moodle-e-1", Status: oneroster.StatusActive, ClassID: "moodle-c-1",
+			SchoolID: "org", UserID: "moodle-u-1", Role: oneroster.RoleStudent,
+		}},
+	}
+
+	report := importBundle(t, app, bulk(t, lms), "en")
+	if report.Users.Created != 1 || report.Classes.Created != 1 || report.Enrollments.Created != 1 ||
+		len(report.Errors) != 0 {
+		t.Fatalf("expected the lms bundle to be created, got %+v", report)
+	}
+
+	exported := exportBundle(t, app)
+
+	want := "class course-00000001, class moodle-c-1, enrollment enrollment-00000001-90101010, " +
+		"enrollment moodle-e-1, user moodle-u-1, user student-90101010"
+	if got := sourcedIDs(exported); got != want {
+		t.Fatalf("expected the sourced ids %s, got %s", want, got)
+	}
+
+	// re-importing the export matches all of its entities.
+	report = importBundle(t, app, bulk(t, exported), "en")
+	if report.Users.Matched != 2 || report.Classes.Matched != 2 || report.Enrollments.Matched != 2 ||
+		report.Users.Created+report.Classes.Created+report.Enrollments.Created != 0 || len(report.Errors) != 0 {
+		t.Fatalf("expected the exported bundle to be matched, got %+v", report)
+	}
+
+	if got := sourcedIDs(exportBundle(t, app)); got != want {
+		t.Errorf("expected the sourced ids %s after the round trip, got %s", want, got)
+	}
+
+	// the deleted enrollment drops the student and the unknown user is reported in the language of the request.
+	changes := delta(t, "moodle-e-1,tobedeleted,moodle-c-1,moodle-u-1,student\n"+
+		"moodle-e-2,active,moodle-c-1,moodle-u-2,student\n")
+
+	report = importBundle(t, app, changes, "fa")
+	if report.Enrollments.Deleted != 1 || len(report.Errors) != 1 ||
+		!strings.HasSuffix(report.Errors[0], i18n.Error(i18n.Persian, sourcedid.ErrSourcedIDNotFound)) {
+		t.Fatalf("expected the enrollment to be deleted and the unknown user to be reported, got %+v", report)
+	}
+
+	enrollments := 0
+
+	err = ss.Enrollments(ctx, func(model.Enrollment) error {
+		enrollments++
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if enrollments != 1 {
+		t.Errorf("expected only the local enrollment to remain, got %d enrollments", enrollments)
+	}
+
+	// deleting it again is skipped.
+	report = importBundle(t, app, changes, "en")
+	if report.Enrollments.Deleted != 0 || report.Enrollments.Skipped != 1 {
+		t.Errorf("expected the deleted enrollment to be skipped, got %+v", report)
+	}
+}
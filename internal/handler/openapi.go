@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// swaggerUI renders the openapi document with the swagger-ui bundle from its CDN.
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Students API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+type OpenAPI struct {
+	Spec *openapi3.T
+}
+
+// Document returns the openapi document.
+func (o OpenAPI) Document(c echo.Context) error {
+	return c.JSON(http.StatusOK, o.Spec)
+}
+
+// Docs renders the openapi document with swagger ui.
+func (o OpenAPI) Docs(c echo.Context) error {
+	return c.HTML(http.StatusOK, swaggerUI)
+}
+
+func (o OpenAPI) Register(g *echo.Group) {
+	g.GET("/openapi.json", o.Document)
+	g.GET("/docs", o.Docs)
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/ical"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v4"
+)
+
+// Schedule exports the weekly timetable of students as iCalendar, the meetings are in the Location
+// time zone and recur during the Term. The calendars are created by the University.
+type Schedule struct {
+	Students   student.Student
+	Courses    course.Course
+	Term       model.Term
+	Location   *time.Location
+	University string
+}
+
+// weekday returns the go weekday of the meeting weekday.
+func weekday(name string) (time.Weekday, bool) {
+	for _, w := range ical.Weekdays {
+		if w.Name == name {
+			return w.Weekday, true
+		}
+	}
+
+	return time.Sunday, false
+}
+
+// ICS returns the timetable of the student. Event UIDs are derived from the term, the course and
+// the weekday and start of the meeting (not its position), so calendar applications update the events
+// on re-import even when the other meetings of the course are changed.
+func (s Schedule) ICS(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := c.Param("id")
+
+	err := validation.Validate(id, validation.Length(StudentIDLen, StudentIDLen), is.Digit)
+	if err != nil {
+		return problem.Param("id", err)
+	}
+
+	st, err := s.Students.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, student.ErrStudentNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeStudentNotFound, "student does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	var events []ical.Event
+
+	for _, cr := range st.Courses {
+		ms, err := s.Courses.Meetings(ctx, cr.ID)
+		if err != nil {
+			return problem.Internal(err)
+		}
+
+		for _, m := range ms {
+			day, ok := weekday(m.Weekday)
+			if !ok {
+				log.Printf("course %s has invalid meeting weekday %s", cr.ID, m.Weekday)
+
+				continue
+			}
+
+			events = append(events, ical.Event{
+				UID: fmt.Sprintf("%s-course-%s-%s-%s@%s", s.Term.Start.Format("20060102"), cr.ID, m.Weekday,
+					strings.ReplaceAll(m.Start, ":", ""), ical.UIDDomain),
+				Summary:  cr.Name,
+				Location: m.Location,
+				Weekday:  day,
+				Start:    m.Start,
+				End:      m.End,
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+
+	err = ical.Write(&buf, ical.Calendar{
+		Name:         st.Name,
+		Location:     s.Location,
+		Start:        s.Term.Start,
+		End:          s.Term.End,
+		Holidays:     s.Term.Holidays,
+		Events:       events,
+		Stamp:        time.Now(),
+		Organization: s.University,
+	})
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=schedule-%s.ics", st.ID))
+
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
+
+func (s Schedule) Register(g *echo.Group) {
+	g.GET("/students/:id/schedule.ics", s.ICS, policy.Require(policy.ReadStudents, policy.StudentParam("id")))
+}
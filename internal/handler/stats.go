@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/store/stats"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultStatsPairs = 10
+	MaxStatsPairs     = 100
+)
+
+type Stats struct {
+	Store stats.Stats
+}
+
+// Get returns the enrollment statistics, pairs query parameter limits the number of course pairings.
+func (s Stats) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	pairs := DefaultStatsPairs
+
+	if v := c.QueryParam("pairs"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil {
+			return problem.Param("pairs", err)
+		}
+
+		err = validation.Validate(p, validation.Min(0), validation.Max(MaxStatsPairs))
+		if err != nil {
+			return problem.Param("pairs", err)
+		}
+
+		pairs = p
+	}
+
+	st, err := s.Store.Summary(ctx, pairs)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, st)
+}
+
+func (s Stats) Register(g *echo.Group) {
+	g.GET("/stats", s.Get, policy.Require(policy.ReadStats, policy.None))
+}
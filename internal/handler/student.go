@@ -1,16 +1,20 @@
 package handler
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"log"
-	"math/big"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/1995parham-teaching/students/internal/idgen"
+	"github.com/1995parham-teaching/students/internal/jalali"
 	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
 	"github.com/1995parham-teaching/students/internal/request"
-	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/response"
 	"github.com/1995parham-teaching/students/internal/store/student"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -22,8 +26,21 @@ const (
 	StudentIDMax = 100_000_000
 )
 
+// Student handles the students, their times are rendered in the Location and in the calendar
+// of the calendar query parameter (gregorian or jalali).
 type Student struct {
-	Store student.Student
+	Store    student.Student
+	Location *time.Location
+}
+
+// calendar reads the calendar query parameter.
+func calendar(c echo.Context) (jalali.Calendar, error) {
+	cal, err := jalali.ParseCalendar(c.QueryParam("calendar"))
+	if err != nil {
+		return "", problem.Param("calendar", err)
+	}
+
+	return cal, nil
 }
 
 func (s Student) Create(c echo.Context) error {
@@ -33,47 +50,109 @@ func (s Student) Create(c echo.Context) error {
 
 	err := c.Bind(&req)
 	if err != nil {
-		log.Println(err)
-
-		return echo.ErrBadRequest
+		return problem.Bind(err)
 	}
 
-	err = req.Validate()
+	cal, err := calendar(c)
 	if err != nil {
-		log.Println(err)
-
-		return echo.ErrBadRequest
+		return err
 	}
 
-	idBig, err := rand.Int(rand.Reader, big.NewInt(StudentIDMax))
+	req.Calendar = cal
+
+	err = req.Validate()
 	if err != nil {
-		panic(err)
+		return problem.Validation(err)
 	}
 
-	st := model.Student{
-		Name:    req.Name,
-		ID:      fmt.Sprintf("%08d", idBig.Int64()),
-		Courses: nil,
-	}
+	st := req.Student(idgen.New(StudentIDMax), time.Now().In(s.Location))
 
 	err = s.Store.Create(ctx, st)
 	if err != nil {
 		if errors.Is(err, student.ErrStudentAlreadyExists) {
-			return echo.ErrBadRequest
+			return problem.New(http.StatusBadRequest, problem.CodeStudentExists, "student already exists")
+		}
+
+		if errors.Is(err, student.ErrNationalIDTaken) {
+			return problem.New(http.StatusConflict, problem.CodeNationalIDTaken, "national code is already taken")
 		}
 
-		return echo.ErrInternalServerError
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusCreated, response.NewStudent(ctx, st, cal, s.Location))
+}
+
+// Import creates students from a csv file with a name and optional id, entrance_year, entrance_semester,
+// email, phone, national_id, birth_date (in the calendar of the query), major and status columns.
+func (s Student) Import(c echo.Context) error {
+	now := time.Now().In(s.Location)
+
+	cal, err := calendar(c)
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, st)
+	return importCSV(c, []string{"name"},
+		func(row csvRow) (model.Student, error) {
+			year := 0
+
+			if v := row.Fields["entrance_year"]; v != "" {
+				y, err := strconv.Atoi(v)
+				if err != nil {
+					return model.Student{}, fmt.Errorf("invalid entrance year %w", err)
+				}
+
+				year = y
+			}
+
+			req := request.StudentCreate{
+				Name:             row.Fields["name"],
+				EntranceYear:     year,
+				EntranceSemester: row.Fields["entrance_semester"],
+				Email:            row.Fields["email"],
+				Phone:            row.Fields["phone"],
+				NationalID:       row.Fields["national_id"],
+				BirthDate:        row.Fields["birth_date"],
+				Major:            row.Fields["major"],
+				Status:           row.Fields["status"],
+				Calendar:         cal,
+			}
+
+			err := req.Validate()
+			if err != nil {
+				return model.Student{}, err
+			}
+
+			id := row.Fields["id"]
+			if id == "" {
+				id = idgen.New(StudentIDMax)
+			}
+
+			err = validation.Validate(id, validation.Length(StudentIDLen, StudentIDLen), is.Digit)
+			if err != nil {
+				return model.Student{}, fmt.Errorf("invalid student id %w", err)
+			}
+
+			return req.Student(id, now), nil
+		},
+		func(st model.Student) string { return st.ID },
+		s.Store.Create,
+		s.Store.CreateAll,
+	)
 }
 
 func (s Student) GetAll(c echo.Context) error {
 	ctx := c.Request().Context()
 
+	cal, err := calendar(c)
+	if err != nil {
+		return err
+	}
+
 	ss, err := s.Store.GetAll(ctx)
 	if err != nil {
-		return echo.ErrInternalServerError
+		return problem.Internal(err)
 	}
 
 	h := c.Request().Header.Get("Students-Fall-2022")
@@ -81,7 +160,7 @@ func (s Student) GetAll(c echo.Context) error {
 
 	c.Response().Header().Add("Students-Fall-2022", "123")
 
-	return c.JSON(http.StatusOK, ss)
+	return c.JSON(http.StatusOK, response.NewStudents(ctx, ss, cal, s.Location))
 }
 
 func (s Student) Get(c echo.Context) error {
@@ -91,56 +170,69 @@ func (s Student) Get(c echo.Context) error {
 
 	err := validation.Validate(id, validation.Length(StudentIDLen, StudentIDLen), is.Digit)
 	if err != nil {
-		return echo.ErrBadRequest
+		return problem.Param("id", err)
+	}
+
+	cal, err := calendar(c)
+	if err != nil {
+		return err
 	}
 
 	st, err := s.Store.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, student.ErrStudentNotFound) {
-			return echo.ErrNotFound
+			return problem.New(http.StatusNotFound, problem.CodeStudentNotFound, "student does not exist")
 		}
 
-		return echo.ErrInternalServerError
+		return problem.Internal(err)
 	}
 
-	return c.JSON(http.StatusOK, st)
+	return c.JSON(http.StatusOK, response.NewStudent(ctx, st, cal, s.Location))
 }
 
+// Fill registers the student, it is deprecated in favor of creating an enrollment.
 func (s Student) Fill(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	sid := c.Param("sid")
-	cid := c.Param("cid")
-
-	err := validation.Validate(sid, validation.Length(StudentIDLen, StudentIDLen), is.Digit)
+	sid, cid, err := params(c, true)
 	if err != nil {
-		return echo.ErrBadRequest
+		return err
 	}
 
-	err = validation.Validate(cid, validation.Length(CourseIDLen, CourseIDLen), is.Digit)
+	deprecated(c, "/v1/students/"+sid+"/enrollments")
+
+	err = s.Store.Register(ctx, sid, cid)
 	if err != nil {
-		return echo.ErrBadRequest
+		return enrollmentProblem(err)
 	}
 
-	err = s.Store.Register(ctx, sid, cid)
+	return c.JSON(http.StatusOK, nil)
+}
+
+// Drop unregisters the student, it is deprecated in favor of deleting the enrollment.
+func (s Student) Drop(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sid, cid, err := params(c, true)
 	if err != nil {
-		if errors.Is(err, student.ErrStudentNotFound) {
-			return echo.ErrNotFound
-		}
+		return err
+	}
 
-		if errors.Is(err, course.ErrCourseNotFound) {
-			return echo.ErrNotFound
-		}
+	deprecated(c, "/v1/students/"+sid+"/enrollments/"+cid)
 
-		return echo.ErrInternalServerError
+	err = s.Store.Unregister(ctx, sid, cid)
+	if err != nil {
+		return enrollmentProblem(err)
 	}
 
-	return c.JSON(http.StatusOK, nil)
+	return c.NoContent(http.StatusNoContent)
 }
 
 func (s Student) Register(g *echo.Group) {
-	g.POST("/students", s.Create)
-	g.GET("/students", s.GetAll)
-	g.GET("/students/:id", s.Get)
-	g.GET("/students/:sid/register/:cid", s.Fill)
+	g.POST("/students", s.Create, policy.Require(policy.WriteStudents, policy.None))
+	g.POST("/students\\:import", s.Import, policy.Require(policy.WriteStudents, policy.None))
+	g.GET("/students", s.GetAll, policy.Require(policy.ListStudents, policy.None))
+	g.GET("/students/:id", s.Get, policy.Require(policy.ReadStudents, policy.StudentParam("id")))
+	g.GET("/students/:sid/register/:cid", s.Fill, policy.Require(policy.WriteEnrollments, policy.StudentParam("sid")))
+	g.DELETE("/students/:sid/register/:cid", s.Drop, policy.Require(policy.WriteEnrollments, policy.StudentParam("sid")))
 }
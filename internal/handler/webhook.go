@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	whstore "github.com/1995parham-teaching/students/internal/store/webhook"
+	"github.com/1995parham-teaching/students/internal/webhook"
+	"github.com/labstack/echo/v4"
+)
+
+// DeliveriesLimit is the maximum number of deliveries which are returned in the delivery log.
+const DeliveriesLimit = 100
+
+type Webhook struct {
+	Store whstore.Webhook
+}
+
+// paramID parses the given path parameter as a webhook or delivery id.
+func paramID(c echo.Context, name string) (uint64, error) {
+	v, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, problem.Param(name, err)
+	}
+
+	return v, nil
+}
+
+func bindWebhook(c echo.Context) (request.Webhook, error) {
+	var req request.Webhook
+
+	err := c.Bind(&req)
+	if err != nil {
+		return req, problem.Bind(err)
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return req, problem.Validation(err)
+	}
+
+	return req, nil
+}
+
+// Create creates a webhook, its secret is only returned here.
+func (h Webhook) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := bindWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	if req.Secret == "" {
+		req.Secret = webhook.NewSecret()
+	}
+
+	if req.Events == nil {
+		req.Events = []string{}
+	}
+
+	w, err := h.Store.Create(ctx, model.Webhook{
+		ID:        0,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+		Active:    req.Active == nil || *req.Active,
+		CreatedAt: time.Time{},
+	})
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusCreated, w)
+}
+
+func (h Webhook) GetAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	ws, err := h.Store.GetAll(ctx)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	for i := range ws {
+		ws[i].Secret = ""
+	}
+
+	return c.JSON(http.StatusOK, ws)
+}
+
+func (h Webhook) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	wid, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	w, err := h.Store.Get(ctx, wid)
+	if err != nil {
+		if errors.Is(err, whstore.ErrWebhookNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeWebhookNotFound, "webhook does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	w.Secret = ""
+
+	return c.JSON(http.StatusOK, w)
+}
+
+// Update replaces the webhook, the secret is kept when it isn't given.
+func (h Webhook) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	wid, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	req, err := bindWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	w, err := h.Store.Get(ctx, wid)
+	if err != nil {
+		if errors.Is(err, whstore.ErrWebhookNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeWebhookNotFound, "webhook does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	w.URL = req.URL
+	w.Events = req.Events
+	w.Active = req.Active == nil || *req.Active
+
+	if w.Events == nil {
+		w.Events = []string{}
+	}
+
+	if req.Secret != "" {
+		w.Secret = req.Secret
+	}
+
+	err = h.Store.Update(ctx, w)
+	if err != nil {
+		if errors.Is(err, whstore.ErrWebhookNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeWebhookNotFound, "webhook does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	w.Secret = ""
+
+	return c.JSON(http.StatusOK, w)
+}
+
+func (h Webhook) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	wid, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.Store.Delete(ctx, wid)
+	if err != nil {
+		if errors.Is(err, whstore.ErrWebhookNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeWebhookNotFound, "webhook does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Deliveries returns the delivery log of the webhook, status=dead returns its dead-letter list.
+func (h Webhook) Deliveries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	wid, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	status := model.DeliveryStatus(c.QueryParam("status"))
+
+	switch status {
+	case "", model.DeliveryPending, model.DeliveryDelivered, model.DeliveryDead:
+	default:
+		return problem.Param("status", nil)
+	}
+
+	_, err = h.Store.Get(ctx, wid)
+	if err != nil {
+		if errors.Is(err, whstore.ErrWebhookNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeWebhookNotFound, "webhook does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	ds, err := h.Store.Deliveries(ctx, wid, status, DeliveriesLimit)
+	if err != nil {
+		return problem.Internal(err)
+	}
+
+	return c.JSON(http.StatusOK, ds)
+}
+
+// Replay sends the delivery again, e.g. to recover a dead delivery after fixing the receiver.
+func (h Webhook) Replay(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	wid, err := paramID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	did, err := paramID(c, "did")
+	if err != nil {
+		return err
+	}
+
+	err = h.Store.Replay(ctx, wid, did)
+	if err != nil {
+		if errors.Is(err, whstore.ErrDeliveryNotFound) {
+			return problem.New(http.StatusNotFound, problem.CodeDeliveryNotFound, "delivery does not exist")
+		}
+
+		return problem.Internal(err)
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+func (h Webhook) Register(g *echo.Group) {
+	manage := policy.Require(policy.ManageWebhooks, policy.None)
+
+	g.POST("/webhooks", h.Create, manage)
+	g.GET("/webhooks", h.GetAll, manage)
+	g.GET("/webhooks/:id", h.Get, manage)
+	g.PUT("/webhooks/:id", h.Update, manage)
+	g.DELETE("/webhooks/:id", h.Delete, manage)
+	g.GET("/webhooks/:id/deliveries", h.Deliveries, manage)
+	g.POST("/webhooks/:id/deliveries/:did/replay", h.Replay, manage)
+}
@@ -0,0 +1,106 @@
+package i18n
+
+// persian is the persian catalog, the ozzo-validation templates keep their parameters (e.g. {{.min}}).
+// nolint: gochecknoglobals, lll
+var persian = map[string]string{
+	// ozzo-validation rules
+	"cannot be blank":                                  "نباید خالی باشد",
+	"must be blank":                                    "باید خالی باشد",
+	"is required":                                      "الزامی است",
+	"must be a valid value":                            "باید یک مقدار معتبر باشد",
+	"must be in a valid format":                        "باید قالب معتبری داشته باشد",
+	"must be a valid date":                             "باید یک تاریخ معتبر باشد",
+	"the date is out of range":                         "تاریخ خارج از محدوده است",
+	"the length must be no more than {{.max}}":         "طول آن نباید بیشتر از {{.max}} باشد",
+	"the length must be no less than {{.min}}":         "طول آن نباید کمتر از {{.min}} باشد",
+	"the length must be exactly {{.min}}":              "طول آن باید دقیقاً {{.min}} باشد",
+	"the length must be between {{.min}} and {{.max}}": "طول آن باید بین {{.min}} و {{.max}} باشد",
+	"the value must be empty":                          "مقدار آن باید خالی باشد",
+	"must be no less than {{.threshold}}":              "نباید کمتر از {{.threshold}} باشد",
+	"must be no greater than {{.threshold}}":           "نباید بیشتر از {{.threshold}} باشد",
+	"must be greater than {{.threshold}}":              "باید بیشتر از {{.threshold}} باشد",
+	"must be less than {{.threshold}}":                 "باید کمتر از {{.threshold}} باشد",
+	"must be a valid email address":                    "باید یک نشانی ایمیل معتبر باشد",
+	"must be a valid URL":                              "باید یک نشانی اینترنتی معتبر باشد",
+	"must contain digits only":                         "فقط باید رقم داشته باشد",
+	"must contain unicode letter characters only":      "فقط باید حرف داشته باشد",
+
+	// requests
+	"must contain a letter":                       "باید حداقل یک حرف داشته باشد",
+	"is too long":                                 "بیش از حد طولانی است",
+	"has a character which is not allowed":        "نویسه‌ای دارد که مجاز نیست",
+	"must be a valid national code":               "باید یک کد ملی معتبر باشد",
+	"must not be in the future":                   "نباید در آینده باشد",
+	"must be in the future":                       "باید در آینده باشد",
+	"must be after 1900":                          "باید پس از سال ۱۹۰۰ میلادی باشد",
+	"must be an http or https url":                "باید یک نشانی http یا https باشد",
+	"must not be an internal address":             "نباید یک نشانی داخلی باشد",
+	"meeting must end after its start":            "جلسه باید پس از شروعش تمام شود",
+	"meeting starts with another meeting":         "جلسه هم‌زمان با جلسه‌ی دیگری شروع می‌شود",
+	"calendar must be gregorian or jalali":        "تقویم باید میلادی یا شمسی باشد",
+	"jalali date is invalid":                      "تاریخ شمسی نامعتبر است",
+	"jalali year is out of the supported range":   "سال شمسی خارج از محدوده‌ی پشتیبانی‌شده است",
+	"pairs must be between 0 and 100":             "تعداد جفت‌ها باید بین ۰ و ۱۰۰ باشد",
+	"is invalid":                                  "نامعتبر است",
+	"student creation request validation failed":  "درخواست ایجاد دانشجو نامعتبر است",
+	"course creation request validation failed":   "درخواست ایجاد درس نامعتبر است",
+	"meeting request validation failed":           "درخواست جلسه نامعتبر است",
+	"meetings request validation failed":          "درخواست جلسه‌ها نامعتبر است",
+	"webhook request validation failed":           "درخواست وب‌هوک نامعتبر است",
+	"login request validation failed":             "درخواست ورود نامعتبر است",
+	"token request validation failed":             "درخواست توکن نامعتبر است",
+	"invalid entrance year":                       "سال ورود نامعتبر است",
+	"invalid course capacity":                     "ظرفیت درس نامعتبر است",
+	"invalid student id":                          "شماره‌ی دانشجویی نامعتبر است",
+	"invalid course id":                           "شماره‌ی درس نامعتبر است",
+	"csv header does not have a required column":  "سرآیند فایل csv یک ستون الزامی را ندارد",
+	"wrong number of fields":                      "تعداد فیلدها نادرست است",
+	"bundle does not have a required file":        "بسته یک فایل الزامی را ندارد",
+	"file header does not have a required column": "سرآیند فایل یک ستون الزامی را ندارد",
+	"unsupported export format":                   "قالب خروجی پشتیبانی نمی‌شود",
+	"does not match the schema":                   "با طرح‌واره مطابقت ندارد",
+
+	// problems
+	"request has invalid fields":              "درخواست فیلدهای نامعتبر دارد",
+	"request has invalid parameters":          "درخواست پارامترهای نامعتبر دارد",
+	"request body is malformed":               "بدنه‌ی درخواست خراب است",
+	"student already exists":                  "دانشجو از قبل وجود دارد",
+	"student does not exist":                  "دانشجو وجود ندارد",
+	"student is not registered in the course": "دانشجو در این درس ثبت‌نام نکرده است",
+	"national id belongs to another student":  "کد ملی متعلق به دانشجوی دیگری است",
+	"national code is already taken":          "کد ملی قبلاً ثبت شده است",
+	"course already exists":                   "درس از قبل وجود دارد",
+	"course does not exist":                   "درس وجود ندارد",
+	"course has reached its capacity":         "ظرفیت درس تکمیل شده است",
+	"sourced id does not exist":               "شناسه‌ی منبع وجود ندارد",
+	"webhook does not exist":                  "وب‌هوک وجود ندارد",
+	"delivery does not exist":                 "ارسال وجود ندارد",
+	"api key does not exist":                  "کلید API وجود ندارد",
+	"tenant is required":                      "مستأجر مشخص نشده است",
+	"tenant header does not match the host":   "سرآیند مستأجر با میزبان مطابقت ندارد",
+	"tenant does not exist":                   "مستأجر وجود ندارد",
+	"authentication is required":              "احراز هویت لازم است",
+	"token is invalid or expired":             "توکن نامعتبر یا منقضی شده است",
+	"username or password is wrong":           "نام کاربری یا گذرواژه نادرست است",
+	"permission denied":                       "دسترسی مجاز نیست",
+	"rate limit is exceeded":                  "از سقف مجاز درخواست‌ها عبور کرده‌اید",
+	"server is busy, try again later":         "سرور مشغول است، بعداً دوباره تلاش کنید",
+	"bundle is too large":                     "بسته بیش از حد بزرگ است",
+	"file is too large":                       "فایل بیش از حد بزرگ است",
+	"internal error":                          "خطای داخلی",
+
+	// statuses
+	"Bad Request":              "درخواست نامعتبر",
+	"Unauthorized":             "احراز هویت نشده",
+	"Forbidden":                "دسترسی غیرمجاز",
+	"Not Found":                "پیدا نشد",
+	"Method Not Allowed":       "متد مجاز نیست",
+	"Not Acceptable":           "قابل پذیرش نیست",
+	"Conflict":                 "تعارض",
+	"Request Entity Too Large": "درخواست بیش از حد بزرگ است",
+	"Unsupported Media Type":   "نوع محتوا پشتیبانی نمی‌شود",
+	"Unprocessable Entity":     "درخواست قابل پردازش نیست",
+	"Too Many Requests":        "درخواست‌های بیش از حد",
+	"Internal Server Error":    "خطای داخلی سرور",
+	"Service Unavailable":      "سرویس در دسترس نیست",
+}
@@ -0,0 +1,159 @@
+// Package i18n translates the messages into the language of the request, which is chosen by the
+// Accept-Language header. The catalogs are keyed by the english messages, so english is the fallback
+// of the languages and the messages which don't have a translation.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// Lang is a supported language.
+type Lang string
+
+const (
+	English Lang = "en"
+	Persian Lang = "fa"
+)
+
+// nolint: gochecknoglobals
+var (
+	// langs are the supported languages in the order of the matcher tags, the first one is the default.
+	langs   = []Lang{English, Persian}
+	matcher = language.NewMatcher([]language.Tag{language.English, language.Persian})
+
+	catalogs = map[Lang]map[string]string{
+		Persian: persian,
+	}
+)
+
+// Parse returns the best supported language of an Accept-Language header, it is english
+// when the header is empty or none of its languages is supported.
+func Parse(accept string) Lang {
+	tags, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+
+	_, i, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return English
+	}
+
+	return langs[i]
+}
+
+type contextKey struct{}
+
+// WithLang returns a context which has the given language.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language of the context, it is english when the context has no language.
+func FromContext(ctx context.Context) Lang {
+	lang, ok := ctx.Value(contextKey{}).(Lang)
+	if !ok {
+		return English
+	}
+
+	return lang
+}
+
+// Middleware sets the language of the request context from its Accept-Language header.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			lang := Parse(c.Request().Header.Get("Accept-Language"))
+
+			c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+			c.Response().Header().Set("Content-Language", string(lang))
+			c.SetRequest(c.Request().WithContext(WithLang(c.Request().Context(), lang)))
+
+			return next(c)
+		}
+	}
+}
+
+// T translates the message, the message itself is returned when it has no translation.
+func T(lang Lang, msg string) string {
+	if t, ok := catalogs[lang][msg]; ok {
+		return t
+	}
+
+	return msg
+}
+
+// Error translates the error message. The ozzo-validation errors are translated by their templates
+// and the wrapped errors are translated part by part, e.g. "invalid course id: the length must be exactly 8".
+func Error(lang Lang, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	// nolint: errorlint
+	switch e := err.(type) {
+	case validation.Errors:
+		return errorsMessage(lang, e)
+	case validation.Error:
+		return e.SetMessage(T(lang, e.Message())).Error()
+	}
+
+	msg := err.Error()
+
+	inner := errors.Unwrap(err)
+	if inner == nil {
+		return T(lang, msg)
+	}
+
+	s := inner.Error()
+
+	i := strings.Index(msg, s)
+	if s == "" || i < 0 {
+		return T(lang, msg)
+	}
+
+	return part(lang, msg[:i]) + Error(lang, inner) + part(lang, msg[i+len(s):])
+}
+
+// part translates a part of the wrapped error message and keeps its separators.
+func part(lang Lang, s string) string {
+	trimmed := strings.Trim(s, " :")
+	if trimmed == "" {
+		return s
+	}
+
+	i := strings.Index(s, trimmed)
+
+	return s[:i] + T(lang, trimmed) + s[i+len(trimmed):]
+}
+
+// errorsMessage renders the validation errors in the same format as ozzo-validation.
+func errorsMessage(lang Lang, es validation.Errors) string {
+	keys := make([]string, 0, len(es))
+	for key := range es {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		// nolint: errorlint
+		if _, ok := es[key].(validation.Errors); ok {
+			parts = append(parts, fmt.Sprintf("%v: (%v)", key, Error(lang, es[key])))
+		} else {
+			parts = append(parts, fmt.Sprintf("%v: %v", key, Error(lang, es[key])))
+		}
+	}
+
+	return strings.Join(parts, "; ") + "."
+}
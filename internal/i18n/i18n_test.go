@@ -0,0 +1,63 @@
+package i18n_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/request"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]i18n.Lang{
+		"":                        i18n.English,
+		"fa":                      i18n.Persian,
+		"fa-IR,fa;q=0.9,en;q=0.8": i18n.Persian,
+		"en-US,en;q=0.9,fa;q=0.8": i18n.English,
+		"de,fa;q=0.5":             i18n.Persian,
+		"de":                      i18n.English,
+		"not a language":          i18n.English,
+	}
+
+	for header, lang := range cases {
+		if got := i18n.Parse(header); got != lang {
+			t.Errorf("expected %s for %q, got %s", lang, header, got)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	length := validation.Validate("123", validation.Length(8, 8))
+
+	cases := []struct {
+		err     error
+		english string
+		persian string
+	}{
+		{length, "the length must be exactly 8", "طول آن باید دقیقاً 8 باشد"},
+		{fmt.Errorf("invalid course id %w", length), "invalid course id the length must be exactly 8", "شماره‌ی درس نامعتبر است طول آن باید دقیقاً 8 باشد"},
+		{errors.New("course does not exist"), "course does not exist", "درس وجود ندارد"}, // nolint: err113
+		{errors.New("untranslated"), "untranslated", "untranslated"},                     // nolint: err113
+		{
+			request.StudentCreate{Name: "", Email: "x"}.Validate(), // nolint: exhaustruct
+			"student creation request validation failed email: must be a valid email address; name: cannot be blank.",
+			"درخواست ایجاد دانشجو نامعتبر است email: باید یک نشانی ایمیل معتبر باشد; name: نباید خالی باشد.",
+		},
+	}
+
+	for _, c := range cases {
+		if got := i18n.Error(i18n.English, c.err); got != c.english || got != c.err.Error() {
+			t.Errorf("expected %q, got %q", c.english, got)
+		}
+
+		if got := i18n.Error(i18n.Persian, c.err); got != c.persian {
+			t.Errorf("expected %q, got %q", c.persian, got)
+		}
+	}
+}
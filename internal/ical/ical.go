@@ -0,0 +1,200 @@
+// Package ical writes the weekly timetables as RFC 5545 calendars, each course meeting is
+// a recurring event in the university time zone which is bounded by the term.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// UIDDomain is the domain part of the event UIDs.
+	UIDDomain = "students.aut.ac.ir"
+
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+	clockFormat    = "15:04"
+
+	// lineLimit is the maximum length of the content lines in octets, excluding the line break.
+	lineLimit = 75
+)
+
+// Weekdays maps the meeting weekdays to their go counterpart, in the order of the iranian week.
+// nolint: gochecknoglobals
+var Weekdays = []struct {
+	Name    string
+	Weekday time.Weekday
+}{
+	{"saturday", time.Saturday},
+	{"sunday", time.Sunday},
+	{"monday", time.Monday},
+	{"tuesday", time.Tuesday},
+	{"wednesday", time.Wednesday},
+	{"thursday", time.Thursday},
+	{"friday", time.Friday},
+}
+
+// Event is a weekly recurring event, Start and End are the clock of the event in HH:MM format.
+type Event struct {
+	UID      string
+	Summary  string
+	Location string
+	Weekday  time.Weekday
+	Start    string
+	End      string
+}
+
+// Calendar is a set of weekly events in a single time zone which recur between Start and End dates,
+// except on the Holidays. The dates are interpreted in the calendar location.
+type Calendar struct {
+	Name     string
+	Location *time.Location
+	Start    time.Time
+	End      time.Time
+	Holidays []time.Time
+	Events   []Event
+	// Stamp is the creation time of the calendar.
+	Stamp time.Time
+	// Organization creates the calendar (e.g. the university), it is the owner of the product identifier.
+	Organization string
+}
+
+// writer writes the content lines with CRLF line breaks and folds the long ones.
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (w *writer) line(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+
+	_, w.err = io.WriteString(w.w, fold(fmt.Sprintf(format, args...))+"\r\n")
+}
+
+// fold splits the line into lines of at most 75 octets without splitting a utf-8 character,
+// continuation lines start with a space.
+func fold(line string) string {
+	var b strings.Builder
+
+	n := 0
+
+	for _, r := range line {
+		size := len(string(r))
+
+		if n+size > lineLimit {
+			b.WriteString("\r\n ")
+
+			n = 1
+		}
+
+		b.WriteRune(r)
+
+		n += size
+	}
+
+	return b.String()
+}
+
+// escape escapes the TEXT values.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// ProductID identifies this service of the organization as the creator of the calendars.
+func ProductID(organization string) string {
+	return "-//" + escape(organization) + "//Students//EN"
+}
+
+func weekdayCode(d time.Weekday) string {
+	return strings.ToUpper(d.String()[:2])
+}
+
+// Write writes the calendar, the events which don't occur in the term are skipped.
+func Write(w io.Writer, c Calendar) error {
+	cw := &writer{w: w, err: nil}
+	tz := c.Location.String()
+
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:%s", ProductID(c.Organization))
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("X-WR-CALNAME:%s", escape(c.Name))
+	cw.line("X-WR-TIMEZONE:%s", tz)
+
+	timezone(cw, c.Location, c.Start, c.End)
+
+	for _, e := range c.Events {
+		err := event(cw, c, e)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.line("END:VCALENDAR")
+
+	return cw.err
+}
+
+func event(cw *writer, c Calendar, e Event) error {
+	start, err := time.Parse(clockFormat, e.Start)
+	if err != nil {
+		return fmt.Errorf("invalid event start %w", err)
+	}
+
+	end, err := time.Parse(clockFormat, e.End)
+	if err != nil {
+		return fmt.Errorf("invalid event end %w", err)
+	}
+
+	first := date(c.Start, c.Location)
+	for first.Weekday() != e.Weekday {
+		first = first.AddDate(0, 0, 1)
+	}
+
+	last := date(c.End, c.Location)
+	if first.After(last) {
+		return nil
+	}
+
+	at := func(day time.Time, clock time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, c.Location)
+	}
+
+	tz := c.Location.String()
+
+	cw.line("BEGIN:VEVENT")
+	cw.line("UID:%s", e.UID)
+	cw.line("DTSTAMP:%sZ", c.Stamp.UTC().Format(dateTimeFormat))
+	cw.line("DTSTART;TZID=%s:%s", tz, at(first, start).Format(dateTimeFormat))
+	cw.line("DTEND;TZID=%s:%s", tz, at(first, end).Format(dateTimeFormat))
+	// UNTIL must be in UTC when the start has a time zone, it includes the last day of the term.
+	cw.line("RRULE:FREQ=WEEKLY;BYDAY=%s;UNTIL=%sZ", weekdayCode(e.Weekday),
+		at(last, end).UTC().Format(dateTimeFormat))
+
+	for _, h := range c.Holidays {
+		h = date(h, c.Location)
+		if h.Weekday() == e.Weekday && !h.Before(first) && !h.After(last) {
+			cw.line("EXDATE;TZID=%s:%s", tz, at(h, start).Format(dateTimeFormat))
+		}
+	}
+
+	cw.line("SUMMARY:%s", escape(e.Summary))
+
+	if e.Location != "" {
+		cw.line("LOCATION:%s", escape(e.Location))
+	}
+
+	cw.line("END:VEVENT")
+
+	return nil
+}
+
+// date returns the midnight of the given date in the location.
+func date(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,128 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/ical"
+)
+
+func write(t *testing.T, zone string, c ical.Calendar) string {
+	t.Helper()
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", zone, err)
+	}
+
+	c.Location = loc
+	c.Start = time.Date(c.Start.Year(), c.Start.Month(), c.Start.Day(), 0, 0, 0, 0, loc)
+	c.End = time.Date(c.End.Year(), c.End.Month(), c.End.Day(), 0, 0, 0, 0, loc)
+
+	var buf bytes.Buffer
+
+	err = ical.Write(&buf, c)
+	if err != nil {
+		t.Fatalf("failed to write calendar: %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestWrite_Tehran(t *testing.T) {
+	t.Parallel()
+
+	out := write(t, "Asia/Tehran", ical.Calendar{
+		Name:     "Parham Alvani",
+		Location: nil,
+		// wednesday
+		Start:    time.Date(2026, time.September, 23, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2027, time.January, 9, 0, 0, 0, 0, time.UTC),
+		Holidays: []time.Time{time.Date(2026, time.October, 3, 0, 0, 0, 0, time.UTC)},
+		Events: []ical.Event{{
+			UID:      "course-1@students",
+			Summary:  "Internet Engineering, Fall",
+			Location: "Room 101",
+			Weekday:  time.Saturday,
+			Start:    "09:00",
+			End:      "10:30",
+		}},
+		Stamp:        time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Organization: "Amirkabir University of Technology",
+	})
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"PRODID:-//Amirkabir University of Technology//Students//EN\r\n",
+		"TZID:Asia/Tehran\r\n",
+		"TZOFFSETTO:+0330\r\n",
+		"UID:course-1@students\r\n",
+		"DTSTART;TZID=Asia/Tehran:20260926T090000\r\n",
+		"DTEND;TZID=Asia/Tehran:20260926T103000\r\n",
+		// the last saturday is the last day of the term, 10:30 in Tehran is 07:00 in UTC.
+		"RRULE:FREQ=WEEKLY;BYDAY=SA;UNTIL=20270109T070000Z\r\n",
+		"EXDATE;TZID=Asia/Tehran:20261003T090000\r\n",
+		"SUMMARY:Internet Engineering\\, Fall\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("expected %q in the calendar:\n%s", line, out)
+		}
+	}
+
+	// there is no daylight saving time in Iran since 2022.
+	if strings.Contains(out, "DAYLIGHT") {
+		t.Errorf("unexpected daylight saving time:\n%s", out)
+	}
+}
+
+func TestWrite_DaylightSaving(t *testing.T) {
+	t.Parallel()
+
+	out := write(t, "Europe/Berlin", ical.Calendar{
+		Name:         "Parham Alvani",
+		Location:     nil,
+		Start:        time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC),
+		Holidays:     nil,
+		Events:       nil,
+		Stamp:        time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Organization: "Amirkabir University of Technology",
+	})
+
+	for _, line := range []string{
+		"BEGIN:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\n",
+		// clocks are turned back at 03:00 summer time.
+		"DTSTART:20261025T030000\r\n",
+		"TZOFFSETFROM:+0200\r\n",
+		"TZOFFSETTO:+0100\r\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("expected %q in the calendar:\n%s", line, out)
+		}
+	}
+}
+
+func TestWrite_Fold(t *testing.T) {
+	t.Parallel()
+
+	out := write(t, "Asia/Tehran", ical.Calendar{
+		Name:         strings.Repeat("برنامه‌ی هفتگی ", 10),
+		Location:     nil,
+		Start:        time.Date(2026, time.September, 23, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2027, time.January, 9, 0, 0, 0, 0, time.UTC),
+		Holidays:     nil,
+		Events:       nil,
+		Stamp:        time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Organization: "Amirkabir University of Technology",
+	})
+
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines of at most 75 octets, got %d: %q", len(line), line)
+		}
+	}
+}
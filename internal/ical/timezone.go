@@ -0,0 +1,75 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// timezone writes the VTIMEZONE of the location with its observances which are in effect
+// between the given dates, they are computed from the go time zone database.
+func timezone(cw *writer, loc *time.Location, start time.Time, end time.Time) {
+	from := date(start, loc).AddDate(0, 0, -1)
+	to := date(end, loc).AddDate(0, 0, 1)
+
+	cw.line("BEGIN:VTIMEZONE")
+	cw.line("TZID:%s", loc.String())
+
+	_, offset := from.Zone()
+	observance(cw, from, offset)
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+
+		_, before := day.Zone()
+		if _, after := next.Zone(); after == before {
+			continue
+		}
+
+		// finds the first second which has the new offset.
+		seconds := int(next.Sub(day) / time.Second)
+		i := sort.Search(seconds, func(i int) bool {
+			_, o := day.Add(time.Duration(i) * time.Second).Zone()
+
+			return o != before
+		})
+
+		observance(cw, day.Add(time.Duration(i)*time.Second), before)
+	}
+
+	cw.line("END:VTIMEZONE")
+}
+
+// observance writes the observance which starts at the given instant, its start is written
+// in the local time of the previous offset as RFC 5545 requires.
+func observance(cw *writer, at time.Time, previous int) {
+	name, offset := at.Zone()
+
+	kind := "STANDARD"
+	if at.IsDST() {
+		kind = "DAYLIGHT"
+	}
+
+	cw.line("BEGIN:%s", kind)
+	cw.line("DTSTART:%s", at.UTC().Add(time.Duration(previous)*time.Second).Format(dateTimeFormat))
+	cw.line("TZOFFSETFROM:%s", utcOffset(previous))
+	cw.line("TZOFFSETTO:%s", utcOffset(offset))
+	cw.line("TZNAME:%s", escape(name))
+	cw.line("END:%s", kind)
+}
+
+// utcOffset formats the offset in seconds as +hhmm, or +hhmmss when it has seconds.
+func utcOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	h, m, s := offset/3600, offset/60%60, offset%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, h, m, s)
+	}
+
+	return fmt.Sprintf("%c%02d%02d", sign, h, m)
+}
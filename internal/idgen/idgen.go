@@ -0,0 +1,29 @@
+// Package idgen generates the zero-padded numeric identifiers of students and courses.
+package idgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mrand "math/rand/v2"
+)
+
+// New generates a random zero-padded identifier in [0, maximum).
+func New(maximum int64) string {
+	idBig, err := rand.Int(rand.Reader, big.NewInt(maximum))
+	if err != nil {
+		panic(err)
+	}
+
+	return format(idBig.Int64())
+}
+
+// NewFrom generates a zero-padded identifier in [0, maximum) from the given source,
+// e.g. a seeded source for reproducible data.
+func NewFrom(r *mrand.Rand, maximum int64) string {
+	return format(r.Int64N(maximum))
+}
+
+func format(id int64) string {
+	return fmt.Sprintf("%08d", id)
+}
@@ -0,0 +1,85 @@
+package jalali
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnknownCalendar = errors.New("calendar must be gregorian or jalali")
+
+// Calendar selects the calendar in which the times are rendered.
+type Calendar string
+
+const (
+	Gregorian Calendar = "gregorian"
+	Jalali    Calendar = "jalali"
+)
+
+// ParseCalendar parses the calendar names, the empty name is the gregorian calendar.
+func ParseCalendar(s string) (Calendar, error) {
+	switch Calendar(s) {
+	case "", Gregorian:
+		return Gregorian, nil
+	case Jalali:
+		return Jalali, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownCalendar, s)
+	}
+}
+
+// Format formats the time as RFC 3339, in the jalali calendar only the date is changed
+// (e.g. 1403-07-28T14:05:00+03:30). The zero time is formatted as an empty string.
+func (c Calendar) Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	if c != Jalali {
+		return t.Format(time.RFC3339)
+	}
+
+	d, err := FromTime(t)
+	if err != nil {
+		return t.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d%s", d.Year, int(d.Month), d.Day, t.Format("T15:04:05Z07:00"))
+}
+
+// FormatDate formats the date of the time as yyyy-mm-dd, the zero time is formatted as an empty string.
+func (c Calendar) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	if c != Jalali {
+		return t.Format(time.DateOnly)
+	}
+
+	d, err := FromTime(t)
+	if err != nil {
+		return t.Format(time.DateOnly)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// ParseDate parses the yyyy-mm-dd dates of the calendar as midnights in utc.
+func (c Calendar) ParseDate(s string) (time.Time, error) {
+	if c != Jalali {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+		}
+
+		return t, nil
+	}
+
+	d, err := Parse(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return d.Time(time.UTC), nil
+}
@@ -0,0 +1,257 @@
+// Package jalali converts dates between the Jalali (Solar Hijri) and Gregorian calendars.
+// Leap years follow the arithmetic of Borkowski with the break years of the astronomical
+// calendar, which matches the official calendar between 1 and 3177.
+package jalali
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	// MinYear and MaxYear are the supported range of the jalali years.
+	MinYear = 1
+	MaxYear = 3177
+
+	// gregorianOffset is the difference between the gregorian and jalali years at the start of the jalali year.
+	gregorianOffset = 621
+	// firstHalf is the number of days in the first six months, which have 31 days.
+	firstHalf = 186
+)
+
+var (
+	ErrOutOfRange  = errors.New("jalali year is out of the supported range")
+	ErrInvalidDate = errors.New("jalali date is invalid")
+)
+
+// layout matches the yyyy/mm/dd and yyyy-mm-dd dates, the separators are checked after matching.
+var layout = regexp.MustCompile(`^(\d{4})([/-])(\d{1,2})([/-])(\d{1,2})$`) // nolint: gochecknoglobals
+
+// breaks are the jalali years in which the 33 years leap cycle restarts.
+// nolint: gochecknoglobals
+var breaks = []int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+type Month int
+
+const (
+	Farvardin Month = 1 + iota
+	Ordibehesht
+	Khordad
+	Tir
+	Mordad
+	Shahrivar
+	Mehr
+	Aban
+	Azar
+	Dey
+	Bahman
+	Esfand
+)
+
+// nolint: gochecknoglobals
+var months = []string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+func (m Month) String() string {
+	if m < Farvardin || m > Esfand {
+		return fmt.Sprintf("%%!Month(%d)", int(m))
+	}
+
+	return months[m-1]
+}
+
+// Date is a day in the jalali calendar.
+type Date struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+// cycle contains the leap state of a jalali year and the day of march in which it starts.
+type cycle struct {
+	// leap is zero for the leap years and one for the years after them.
+	leap  int
+	march int
+}
+
+// div and mod are the floored division and modulo.
+func div(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
+
+func mod(a, b int) int {
+	return a - div(a, b)*b
+}
+
+func calendar(year int) (cycle, error) {
+	if year < MinYear || year > MaxYear {
+		return cycle{}, fmt.Errorf("%w: %d", ErrOutOfRange, year)
+	}
+
+	leapJ := -14
+	jp := breaks[0]
+	jump := 0
+
+	for _, jm := range breaks[1:] {
+		jump = jm - jp
+		if year < jm {
+			break
+		}
+
+		leapJ += div(jump, 33)*8 + div(mod(jump, 33), 4)
+		jp = jm
+	}
+
+	n := year - jp
+
+	leapJ += div(n, 33)*8 + div(mod(n, 33)+3, 4)
+	if mod(jump, 33) == 4 && jump-n == 4 {
+		leapJ++
+	}
+
+	gy := year + gregorianOffset
+	leapG := div(gy, 4) - div((div(gy, 100)+1)*3, 4) - 150
+
+	if jump-n < 6 {
+		n = n - jump + div(jump+4, 33)*33
+	}
+
+	leap := mod(mod(n+1, 33)-1, 4)
+	if leap == -1 {
+		leap = 4
+	}
+
+	return cycle{
+		leap:  leap,
+		march: 20 + leapJ - leapG,
+	}, nil
+}
+
+// IsLeap reports whether the year has 366 days, the years out of the supported range are not leap.
+func IsLeap(year int) bool {
+	c, err := calendar(year)
+
+	return err == nil && c.leap == 0
+}
+
+// Days returns the number of days in the month.
+func Days(year int, month Month) int {
+	switch {
+	case month <= Shahrivar:
+		return 31
+	case month < Esfand:
+		return 30
+	case IsLeap(year):
+		return 30
+	default:
+		return 29
+	}
+}
+
+// New returns the date after checking its validity.
+func New(year int, month Month, day int) (Date, error) {
+	if year < MinYear || year > MaxYear {
+		return Date{}, fmt.Errorf("%w: %d", ErrOutOfRange, year)
+	}
+
+	if month < Farvardin || month > Esfand || day < 1 || day > Days(year, month) {
+		return Date{}, fmt.Errorf("%w: %04d/%02d/%02d", ErrInvalidDate, year, month, day)
+	}
+
+	return Date{Year: year, Month: month, Day: day}, nil
+}
+
+// FromTime returns the jalali date of the time in its own location.
+func FromTime(t time.Time) (Date, error) {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	year := y - gregorianOffset
+
+	c, err := calendar(year)
+	if err != nil {
+		return Date{}, err
+	}
+
+	k := days(day.Sub(time.Date(y, time.March, c.march, 0, 0, 0, 0, time.UTC)))
+
+	if k >= 0 {
+		if k < firstHalf {
+			return Date{Year: year, Month: Month(1 + k/31), Day: k%31 + 1}, nil
+		}
+
+		k -= firstHalf
+	} else {
+		// the date is in the last months of the previous year.
+		year--
+
+		if year < MinYear {
+			return Date{}, fmt.Errorf("%w: %d", ErrOutOfRange, year)
+		}
+
+		k += 179
+		if c.leap == 1 {
+			k++
+		}
+	}
+
+	return Date{Year: year, Month: Month(7 + k/30), Day: k%30 + 1}, nil
+}
+
+// Time returns the midnight of the date in the given location.
+func (d Date) Time(loc *time.Location) time.Time {
+	c, err := calendar(d.Year)
+	if err != nil {
+		return time.Time{}
+	}
+
+	k := int(d.Month-1)*31 - div(int(d.Month), 7)*int(d.Month-7) + d.Day - 1
+
+	return time.Date(d.Year+gregorianOffset, time.March, c.march+k, 0, 0, 0, 0, loc)
+}
+
+// Weekday returns the day of the week of the date.
+func (d Date) Weekday() time.Weekday {
+	return d.Time(time.UTC).Weekday()
+}
+
+// AddDays returns the date which is n days after the date.
+func (d Date) AddDays(n int) (Date, error) {
+	return FromTime(d.Time(time.UTC).AddDate(0, 0, n))
+}
+
+// String formats the date as yyyy/mm/dd.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d/%02d/%02d", d.Year, int(d.Month), d.Day)
+}
+
+// Parse parses the dates in the yyyy/mm/dd or yyyy-mm-dd formats.
+func Parse(s string) (Date, error) {
+	m := layout.FindStringSubmatch(s)
+	if m == nil || m[2] != m[4] {
+		return Date{}, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+	}
+
+	year, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[3])
+	day, _ := strconv.Atoi(m[5])
+
+	return New(year, Month(month), day)
+}
+
+func days(d time.Duration) int {
+	return int(d.Round(time.Hour) / (24 * time.Hour))
+}
@@ -0,0 +1,167 @@
+package jalali_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/jalali"
+)
+
+func TestFromTime(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		gregorian string
+		jalali    string
+	}{
+		{"2024-03-20", "1403/01/01"},
+		{"2025-03-20", "1403/12/30"},
+		{"2025-03-21", "1404/01/01"},
+		{"2021-03-20", "1399/12/30"},
+		{"2022-09-23", "1401/07/01"},
+		{"2023-01-21", "1401/11/01"},
+		{"1979-02-11", "1357/11/22"},
+		{"2000-01-01", "1378/10/11"},
+		{"2026-10-19", "1405/07/27"},
+	}
+
+	for _, c := range cases {
+		g, err := time.Parse(time.DateOnly, c.gregorian)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d, err := jalali.FromTime(g)
+		if err != nil {
+			t.Fatalf("failed to convert %s: %v", c.gregorian, err)
+		}
+
+		if d.String() != c.jalali {
+			t.Errorf("expected %s to be %s, got %s", c.gregorian, c.jalali, d)
+		}
+
+		j, err := jalali.Parse(c.jalali)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", c.jalali, err)
+		}
+
+		if got := j.Time(time.UTC); !got.Equal(g) {
+			t.Errorf("expected %s to be %s, got %s", c.jalali, c.gregorian, got.Format(time.DateOnly))
+		}
+	}
+}
+
+// TestRoundTrip converts every day of two centuries in both directions.
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	prev, err := jalali.FromTime(start.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for day := start; day.Year() < 2100; day = day.AddDate(0, 0, 1) {
+		d, err := jalali.FromTime(day)
+		if err != nil {
+			t.Fatalf("failed to convert %s: %v", day.Format(time.DateOnly), err)
+		}
+
+		if !d.Time(time.UTC).Equal(day) {
+			t.Fatalf("expected %s to be converted back to %s", d, day.Format(time.DateOnly))
+		}
+
+		// dates are consecutive, so the months have the right number of days.
+		next, err := prev.AddDays(1)
+		if err != nil || next != d {
+			t.Fatalf("expected %s after %s, got %s", d, prev, next)
+		}
+
+		prev = d
+	}
+}
+
+func TestIsLeap(t *testing.T) {
+	t.Parallel()
+
+	for _, y := range []int{1370, 1375, 1379, 1383, 1387, 1391, 1395, 1399, 1403, 1408} {
+		if !jalali.IsLeap(y) {
+			t.Errorf("expected %d to be leap", y)
+		}
+	}
+
+	for _, y := range []int{1400, 1401, 1402, 1404, 1405, 1407} {
+		if jalali.IsLeap(y) {
+			t.Errorf("expected %d not to be leap", y)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	d, err := jalali.Parse("1403-7-1")
+	if err != nil || d != (jalali.Date{Year: 1403, Month: jalali.Mehr, Day: 1}) {
+		t.Errorf("expected 1403/07/01, got %v (%v)", d, err)
+	}
+
+	for _, s := range []string{"1404/12/30", "1403/13/01", "1403/07/31", "1403/07-01", "14030701", "0000/01/01"} {
+		_, err := jalali.Parse(s)
+		if !errors.Is(err, jalali.ErrInvalidDate) && !errors.Is(err, jalali.ErrOutOfRange) {
+			t.Errorf("expected %s to be invalid, got %v", s, err)
+		}
+	}
+}
+
+func TestCalendar_Format(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Asia/Tehran")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// it is still the last day of 1403 in utc.
+	ts := time.Date(2025, time.March, 20, 22, 0, 0, 0, time.UTC).In(loc)
+
+	if got := jalali.Jalali.Format(ts); got != "1404-01-01T01:30:00+03:30" {
+		t.Errorf("expected jalali time, got %s", got)
+	}
+
+	if got := jalali.Gregorian.Format(ts); got != "2025-03-21T01:30:00+03:30" {
+		t.Errorf("expected gregorian time, got %s", got)
+	}
+
+	if got := jalali.Jalali.Format(time.Time{}); got != "" {
+		t.Errorf("expected empty zero time, got %s", got)
+	}
+
+	_, err = jalali.ParseCalendar("hijri")
+	if !errors.Is(err, jalali.ErrUnknownCalendar) {
+		t.Errorf("expected ErrUnknownCalendar, got %v", err)
+	}
+}
+
+func TestCalendar_Date(t *testing.T) {
+	t.Parallel()
+
+	d, err := jalali.Jalali.ParseDate("1378-10-11")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := jalali.Gregorian.FormatDate(d); got != "2000-01-01" {
+		t.Errorf("expected 2000-01-01, got %s", got)
+	}
+
+	if got := jalali.Jalali.FormatDate(d); got != "1378-10-11" {
+		t.Errorf("expected 1378-10-11, got %s", got)
+	}
+
+	_, err = jalali.Gregorian.ParseDate("2000-02-30")
+	if !errors.Is(err, jalali.ErrInvalidDate) {
+		t.Errorf("expected ErrInvalidDate, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTP holds the request metrics of the echo server, requests are labeled by their
+// route path instead of the raw URL to keep the cardinality low.
+type HTTP struct {
+	Requests *prometheus.CounterVec
+	Latency  *prometheus.HistogramVec
+	InFlight prometheus.Gauge
+}
+
+func NewHTTP(reg prometheus.Registerer) HTTP {
+	m := HTTP{
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{ // nolint: exhaustruct
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "number of handled http requests",
+		}, []string{"method", "path", "code"}),
+		Latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{ // nolint: exhaustruct
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "latency of http requests in seconds",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "path"}),
+		InFlight: prometheus.NewGauge(prometheus.GaugeOpts{ // nolint: exhaustruct
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "in_flight_requests",
+			Help:      "number of http requests which are being served right now",
+		}),
+	}
+
+	reg.MustRegister(m.Requests, m.Latency, m.InFlight)
+
+	return m
+}
+
+func (m HTTP) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			m.InFlight.Inc()
+			defer m.InFlight.Dec()
+
+			err := next(c)
+
+			code := c.Response().Status
+
+			// the error is not written yet, because the echo error handler runs after the middlewares.
+			if err != nil {
+				code = problem.Status(err)
+			}
+
+			path := c.Path()
+			if path == "" {
+				path = "unknown"
+			}
+
+			m.Requests.WithLabelValues(c.Request().Method, path, strconv.Itoa(code)).Inc()
+			m.Latency.WithLabelValues(c.Request().Method, path).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Store holds the metrics that are shared between store decorators.
+// Every metric is labeled by the store name (e.g. student) and its method.
+type Store struct {
+	Latency  *prometheus.HistogramVec
+	Errors   *prometheus.CounterVec
+	InFlight *prometheus.GaugeVec
+}
+
+func NewStore(reg prometheus.Registerer) Store {
+	m := Store{
+		Latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{ // nolint: exhaustruct
+			Namespace: Namespace,
+			Subsystem: "store",
+			Name:      "duration_seconds",
+			Help:      "latency of store methods in seconds",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"store", "method"}),
+		Errors: prometheus.NewCounterVec(prometheus.CounterOpts{ // nolint: exhaustruct
+			Namespace: Namespace,
+			Subsystem: "store",
+			Name:      "errors_total",
+			Help:      "number of failed store calls by their domain error",
+		}, []string{"store", "method", "error"}),
+		InFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{ // nolint: exhaustruct
+			Namespace: Namespace,
+			Subsystem: "store",
+			Name:      "in_flight",
+			Help:      "number of store calls which are running right now",
+		}, []string{"store", "method"}),
+	}
+
+	reg.MustRegister(m.Latency, m.Errors, m.InFlight)
+
+	return m
+}
+
+// Start marks the beginning of a store call, the returned function must be called
+// when the call is finished with the error label, or an empty string on success.
+func (m Store) Start(store, method string) func(label string) {
+	start := time.Now()
+
+	m.InFlight.WithLabelValues(store, method).Inc()
+
+	return func(label string) {
+		m.InFlight.WithLabelValues(store, method).Dec()
+		m.Latency.WithLabelValues(store, method).Observe(time.Since(start).Seconds())
+
+		if label != "" {
+			m.Errors.WithLabelValues(store, method, label).Inc()
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// APIKey authenticates a non-interactive client, e.g. a sync job, with a fixed set of scopes
+// instead of a role. The key itself is only shown on creation and rotation, only its hash is kept.
+type APIKey struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Hash   []byte   `json:"-"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is nil for the keys which never expire.
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Expired reports whether the key is expired at the given time.
+func (k APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Meeting is a weekly class of a course, Start and End are the local time of the class
+// in HH:MM format and Weekday is the lowercase english name of the day (e.g. saturday).
+type Meeting struct {
+	Weekday  string `json:"weekday"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location,omitempty"`
+}
+
+// Term bounds the weekly meetings of the courses, the classes are not held on holidays.
+// All the dates are midnights in the university time zone.
+type Term struct {
+	Start    time.Time
+	End      time.Time
+	Holidays []time.Time
+}
@@ -0,0 +1,32 @@
+package model
+
+// Stats summarizes the registrations for the department heads.
+type Stats struct {
+	Students    int           `json:"students"`
+	Courses     int           `json:"courses"`
+	Enrollments int           `json:"enrollments"`
+	PerCourse   []CourseStats `json:"per_course"`
+	Load        []CourseLoad  `json:"load"`
+	Pairs       []CoursePair  `json:"pairs"`
+}
+
+// CourseStats is the enrollment of a course, FillRate is the ratio of enrolled students
+// to the capacity and it is nil for the courses without capacity.
+type CourseStats struct {
+	Course   Course   `json:"course"`
+	Enrolled int      `json:"enrolled"`
+	FillRate *float64 `json:"fill_rate"`
+}
+
+// CourseLoad is the number of students which are registered in exactly Courses courses.
+type CourseLoad struct {
+	Courses  int `json:"courses"`
+	Students int `json:"students"`
+}
+
+// CoursePair is the number of students which are registered in both courses.
+type CoursePair struct {
+	First    Course `json:"first"`
+	Second   Course `json:"second"`
+	Students int    `json:"students"`
+}
@@ -1,12 +1,115 @@
 package model
 
+import (
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/jalali"
+)
+
+// Student has optional profile fields, email, phone, national id and birth date are
+// personally identifiable and they are hidden from the callers which cannot see them.
 type Student struct {
-	Name    string   `json:"name"`
-	ID      string   `json:"id"`
-	Courses []Course `json:"courses"`
+	Name       string        `json:"name"`
+	ID         string        `json:"id"`
+	Courses    []Course      `json:"courses"`
+	Entrance   Entrance      `json:"entrance"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Email      string        `json:"email,omitempty"`
+	Phone      string        `json:"phone,omitempty"`
+	NationalID string        `json:"national_id,omitempty"`
+	BirthDate  time.Time     `json:"birth_date"`
+	Major      string        `json:"major,omitempty"`
+	Status     StudentStatus `json:"status"`
+}
+
+// Redacted returns the student without its personally identifiable information.
+func (s Student) Redacted() Student {
+	s.Email = ""
+	s.Phone = ""
+	s.NationalID = ""
+	s.BirthDate = time.Time{}
+
+	return s
+}
+
+type StudentStatus string
+
+const (
+	StudentActive    StudentStatus = "active"
+	StudentGraduated StudentStatus = "graduated"
+	StudentSuspended StudentStatus = "suspended"
+	StudentWithdrawn StudentStatus = "withdrawn"
+)
+
+// StudentStatuses returns all the student statuses.
+func StudentStatuses() []StudentStatus {
+	return []StudentStatus{StudentActive, StudentGraduated, StudentSuspended, StudentWithdrawn}
 }
 
+// Semester is a half of the academic year, the fall semester starts in Mehr and the spring semester in Bahman.
+type Semester string
+
+const (
+	SemesterFall   Semester = "fall"
+	SemesterSpring Semester = "spring"
+)
+
+// Entrance is the academic year and semester in which the student is admitted, the year is
+// in the jalali calendar (e.g. 1401 for 1401-1402).
+type Entrance struct {
+	Year     int      `json:"year"`
+	Semester Semester `json:"semester"`
+}
+
+// EntranceOf returns the semester which is running on the date, the summer belongs
+// to the spring semester of the previous academic year.
+func EntranceOf(d jalali.Date) Entrance {
+	switch {
+	case d.Month < jalali.Mehr:
+		return Entrance{Year: d.Year - 1, Semester: SemesterSpring}
+	case d.Month < jalali.Bahman:
+		return Entrance{Year: d.Year, Semester: SemesterFall}
+	default:
+		return Entrance{Year: d.Year, Semester: SemesterSpring}
+	}
+}
+
+// Course has an optional capacity, zero capacity means there is no limit on its students.
 type Course struct {
-	Name string `json:"name,omitempty"`
-	ID   string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Capacity int    `json:"capacity,omitempty"`
+	// Instructor is the username of the instructor who can see the course roster.
+	Instructor string `json:"instructor,omitempty"`
+}
+
+// CourseSeats is the number of students which are registered in a course.
+type CourseSeats struct {
+	CourseID string `json:"course_id"`
+	Enrolled int    `json:"enrolled"`
+}
+
+type EnrollmentStatus string
+
+const (
+	EnrollmentEnrolled EnrollmentStatus = "enrolled"
+	// EnrollmentDropped is the status of an enrollment which is just dropped, the dropped enrollments are not kept.
+	EnrollmentDropped EnrollmentStatus = "dropped"
+)
+
+// EnrollmentStatuses returns all the enrollment statuses.
+func EnrollmentStatuses() []EnrollmentStatus {
+	return []EnrollmentStatus{EnrollmentEnrolled, EnrollmentDropped}
+}
+
+// Enrollment is a single registration of a student into a course.
+type Enrollment struct {
+	StudentID   string           `json:"student_id"`
+	StudentName string           `json:"student_name"`
+	CourseID    string           `json:"course_id"`
+	CourseName  string           `json:"course_name"`
+	Status      EnrollmentStatus `json:"status"`
+	// EnrolledAt is nil for the enrollments which are older than their registration time.
+	EnrolledAt *time.Time `json:"enrolled_at"`
+	DroppedAt  *time.Time `json:"dropped_at,omitempty"`
 }
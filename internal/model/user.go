@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Role decides what a user is allowed to do.
+type Role string
+
+const (
+	RoleStudent    Role = "student"
+	RoleInstructor Role = "instructor"
+	RoleAdmin      Role = "admin"
+)
+
+// Roles returns all the roles.
+func Roles() []Role {
+	return []Role{RoleStudent, RoleInstructor, RoleAdmin}
+}
+
+// User can log in with its password, the users with the student role are the students
+// with the given StudentID.
+type User struct {
+	Username     string    `json:"username"`
+	PasswordHash []byte    `json:"-"`
+	Role         Role      `json:"role"`
+	StudentID    string    `json:"student_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,156 @@
+// Package names normalizes the Persian and English names and validates them against a policy,
+// names are normalized before validation and storage so the same name is always stored the same way.
+package names
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ZWNJ is the zero-width non-joiner which separates the parts of the Persian words (e.g. "می‌خواهم").
+const ZWNJ = '\u200c'
+
+var (
+	ErrEmpty     = errors.New("must contain a letter")
+	ErrTooLong   = errors.New("is too long")
+	ErrCharacter = errors.New("has a character which is not allowed")
+)
+
+// replacer maps the arabic letters and the digits to their persian and ascii counterparts.
+// nolint: gochecknoglobals
+var replacer = strings.NewReplacer(
+	"\u064a", "\u06cc", // arabic yeh
+	"\u0649", "\u06cc", // alef maksura
+	"\u0643", "\u06a9", // arabic kaf
+	"\u0640", "", // tatweel
+	"\u200e", "", // left-to-right mark
+	"\u200f", "", // right-to-left mark
+	"\ufeff", "", // byte order mark
+	"\u06f0", "0", "\u06f1", "1", "\u06f2", "2", "\u06f3", "3", "\u06f4", "4",
+	"\u06f5", "5", "\u06f6", "6", "\u06f7", "7", "\u06f8", "8", "\u06f9", "9",
+	"\u0660", "0", "\u0661", "1", "\u0662", "2", "\u0663", "3", "\u0664", "4",
+	"\u0665", "5", "\u0666", "6", "\u0667", "7", "\u0668", "8", "\u0669", "9",
+)
+
+// Normalize converts the arabic yeh and kaf and the persian and arabic digits, trims and collapses
+// the whitespaces and removes the extra non-joiners, e.g. those which are repeated or next to a space.
+func Normalize(s string) string {
+	s = replacer.Replace(norm.NFC.String(s))
+
+	var b strings.Builder
+
+	// pending is the separator which is written before the next character, if there is one.
+	var pending rune
+
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			if b.Len() != 0 {
+				pending = ' '
+			}
+		case r == ZWNJ:
+			if b.Len() != 0 && pending == 0 {
+				pending = ZWNJ
+			}
+		default:
+			if pending != 0 {
+				b.WriteRune(pending)
+
+				pending = 0
+			}
+
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// Class is a set of characters.
+type Class func(r rune) bool
+
+// Letters contains the letters of all languages and their combining marks (e.g. the Persian diacritics).
+func Letters(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+}
+
+// Digits contains the ascii digits, the other digits are converted to them by Normalize.
+func Digits(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// Chars returns the class of the given characters.
+func Chars(chars string) Class {
+	return func(r rune) bool {
+		return strings.ContainsRune(chars, r)
+	}
+}
+
+// Policy is the characters which are allowed in a name, every name must have a letter.
+// It is an ozzo-validation rule which validates the normalized names.
+type Policy struct {
+	Classes   []Class
+	MaxLength int
+}
+
+// nolint: gochecknoglobals
+var (
+	// Person allows the names like "Mohammad-Reza O'Neil Jr." and "علی‌اکبر".
+	Person = Policy{
+		Classes:   []Class{Letters, Chars(" -'.\u200c")},
+		MaxLength: 128,
+	}
+	// Course allows the names like "C++ Programming", "Calculus 2" and "Data Structures & Algorithms (II)".
+	Course = Policy{
+		Classes:   []Class{Letters, Digits, Chars(" -'.,:&+#/()\u200c")},
+		MaxLength: 128,
+	}
+)
+
+func (p Policy) allowed(r rune) bool {
+	for _, c := range p.Classes {
+		if c(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate validates the name after normalizing it, empty names are valid so they must be checked
+// with validation.Required.
+func (p Policy) Validate(value any) error {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return nil
+	}
+
+	s = Normalize(s)
+
+	if p.MaxLength > 0 && utf8.RuneCountInString(s) > p.MaxLength {
+		return ErrTooLong
+	}
+
+	letter := false
+
+	for _, r := range s {
+		if !p.allowed(r) {
+			return fmt.Errorf("%w: %q", ErrCharacter, r)
+		}
+
+		if unicode.IsLetter(r) {
+			letter = true
+		}
+	}
+
+	if !letter {
+		return ErrEmpty
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package names_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/names"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{"whitespace", "  Parham \t  Alvani\n", "Parham Alvani"},
+		{"arabic yeh and kaf", "علي كاظمي", "علی کاظمی"},
+		{"persian digits", "ریاضی ۲", "ریاضی 2"},
+		{"arabic digits", "ریاضی ٢", "ریاضی 2"},
+		{"tatweel", "مـحمد", "محمد"},
+		{"repeated non-joiner", "\u0639\u0644\u06cc\u200c\u200c\u0627\u06a9\u0628\u0631", "\u0639\u0644\u06cc\u200c\u0627\u06a9\u0628\u0631"},
+		{"non-joiner next to space", "\u200cParham\u200c Alvani \u200cJr\u200c", "Parham Alvani Jr"},
+		{"non-breaking space", "Parham\u00a0Alvani", "Parham Alvani"},
+		{"decomposed", "Jose\u0301", "Jos\u00e9"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := names.Normalize(c.in); got != c.out {
+				t.Errorf("expected %q, got %q", c.out, got)
+			}
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		policy names.Policy
+		name   string
+		err    error
+	}{
+		{names.Person, "Parham Alvani", nil},
+		{names.Person, "Mohammad-Reza O'Neil Jr.", nil},
+		{names.Person, "\u0639\u0644\u06cc\u200c\u0627\u06a9\u0628\u0631", nil},
+		{names.Person, "اِلهه", nil},
+		{names.Person, "Parham 2", names.ErrCharacter},
+		{names.Person, "Parham@Alvani", names.ErrCharacter},
+		{names.Person, " - ", names.ErrEmpty},
+		{names.Course, "C++ Programming", nil},
+		{names.Course, "Calculus ۲", nil},
+		{names.Course, "Data Structures & Algorithms (II)", nil},
+		{names.Course, "C# .NET", nil},
+		{names.Course, "123", names.ErrEmpty},
+		{names.Course, "Internet Engineering;", names.ErrCharacter},
+		{names.Policy{Classes: []names.Class{names.Letters}, MaxLength: 3}, "Parham", names.ErrTooLong},
+	}
+
+	for _, c := range cases {
+		err := c.policy.Validate(c.name)
+		if !errors.Is(err, c.err) {
+			t.Errorf("expected %v for %q, got %v", c.err, c.name, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Package nationalid validates the Iranian national codes (code-e melli), which are ten digits
+// and the last one is a check digit.
+package nationalid
+
+const length = 10
+
+// Valid checks the length and the check digit of the code. The codes with ten equal digits
+// pass the checksum but they are not issued.
+func Valid(code string) bool {
+	if len(code) != length {
+		return false
+	}
+
+	same := true
+	sum := 0
+
+	for i := range length {
+		d := code[i]
+		if d < '0' || d > '9' {
+			return false
+		}
+
+		if d != code[0] {
+			same = false
+		}
+
+		if i < length-1 {
+			sum += int(d-'0') * (length - i)
+		}
+	}
+
+	if same {
+		return false
+	}
+
+	check := int(code[length-1] - '0')
+
+	r := sum % 11
+	if r < 2 {
+		return check == r
+	}
+
+	return check == 11-r
+}
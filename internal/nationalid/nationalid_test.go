@@ -0,0 +1,23 @@
+package nationalid_test
+
+import (
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/nationalid"
+)
+
+func TestValid(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []string{"0499370899", "0790419904", "0084575948", "0010350829", "4608968882"} {
+		if !nationalid.Valid(code) {
+			t.Errorf("expected %s to be valid", code)
+		}
+	}
+
+	for _, code := range []string{"0499370898", "1111111111", "0000000000", "049937089", "04993708990", "049937089x", ""} {
+		if nationalid.Valid(code) {
+			t.Errorf("expected %s to be invalid", code)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package oneroster reads and writes IMS OneRoster 1.1 CSV bundles (zip files),
+// only the users, classes and enrollments are used by this service and other files are
+// written with the minimum information which is required by the specification.
+package oneroster
+
+import "errors"
+
+const (
+	StatusActive      = "active"
+	StatusToBeDeleted = "tobedeleted"
+
+	RoleStudent = "student"
+
+	ClassTypeScheduled = "scheduled"
+
+	OrgTypeSchool = "school"
+
+	SessionTypeSchoolYear = "schoolYear"
+)
+
+const (
+	FileManifest         = "manifest.csv"
+	FileOrgs             = "orgs.csv"
+	FileAcademicSessions = "academicSessions.csv"
+	FileCourses          = "courses.csv"
+	FileClasses          = "classes.csv"
+	FileUsers            = "users.csv"
+	FileEnrollments      = "enrollments.csv"
+)
+
+var (
+	ErrMissingFile   = errors.New("bundle does not have a required file")
+	ErrMissingColumn = errors.New("file header does not have a required column")
+)
+
+// User is a row of users.csv.
+type User struct {
+	Line int
+
+	SourcedID  string
+	Status     string
+	Role       string
+	Username   string
+	GivenName  string
+	FamilyName string
+	Identifier string
+	Enabled    bool
+}
+
+// Class is a row of classes.csv, each class is bound to a course with the same sourced id.
+type Class struct {
+	Line int
+
+	SourcedID string
+	Status    string
+	Title     string
+	ClassCode string
+	CourseID  string
+	SchoolID  string
+	TermIDs   string
+}
+
+// Enrollment is a row of enrollments.csv.
+type Enrollment struct {
+	Line int
+
+	SourcedID string
+	Status    string
+	ClassID   string
+	SchoolID  string
+	UserID    string
+	Role      string
+}
+
+// Bundle contains the data of a OneRoster bulk bundle, Org and Term are used for all the classes.
+type Bundle struct {
+	SystemName string
+
+	OrgID   string
+	OrgName string
+
+	TermID    string
+	TermTitle string
+	TermStart string
+	TermEnd   string
+
+	Users       []User
+	Classes     []Class
+	Enrollments []Enrollment
+}
@@ -0,0 +1,59 @@
+package oneroster_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/oneroster"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	b := oneroster.Bundle{
+		SystemName: "students",
+		OrgID:      "org",
+		OrgName:    "Amirkabir University of Technology",
+		TermID:     "term",
+		TermTitle:  "2022-2023",
+		TermStart:  "2022-09-01",
+		TermEnd:    "2023-08-31",
+		Users: []oneroster.User{{
+			Line: 0, SourcedID: "moodle-u-1", Status: "", Role: oneroster.RoleStudent, Username: "12345678",
+			GivenName: "Parham", FamilyName: "Alvani", Identifier: "12345678", Enabled: true,
+		}},
+		Classes: []oneroster.Class{{
+			Line: 0, SourcedID: "moodle-c-1", Status: "", Title: "Internet Engineering", ClassCode: "10101010",
+			CourseID: "moodle-c-1", SchoolID: "org", TermIDs: "term",
+		}},
+		Enrollments: []oneroster.Enrollment{{
+			Line: 0, SourcedID: "moodle-e-1", Status: "", ClassID: "moodle-c-1", SchoolID: "org",
+			UserID: "moodle-u-1", Role: oneroster.RoleStudent,
+		}},
+	}
+
+	var buf bytes.Buffer
+
+	if err := oneroster.Write(&buf, b); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	got, err := oneroster.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read bundle: %v", err)
+	}
+
+	if len(got.Users) != 1 || got.Users[0].SourcedID != "moodle-u-1" || got.Users[0].GivenName != "Parham" ||
+		got.Users[0].FamilyName != "Alvani" || got.Users[0].Line != 2 {
+		t.Errorf("unexpected users %+v", got.Users)
+	}
+
+	if len(got.Classes) != 1 || got.Classes[0].SourcedID != "moodle-c-1" || got.Classes[0].Title != "Internet Engineering" {
+		t.Errorf("unexpected classes %+v", got.Classes)
+	}
+
+	if len(got.Enrollments) != 1 || got.Enrollments[0].ClassID != "moodle-c-1" ||
+		got.Enrollments[0].UserID != "moodle-u-1" || got.Enrollments[0].Role != oneroster.RoleStudent {
+		t.Errorf("unexpected enrollments %+v", got.Enrollments)
+	}
+}
@@ -0,0 +1,126 @@
+package oneroster
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Read reads users, classes and enrollments of a OneRoster 1.1 zip bundle,
+// other files are ignored.
+func Read(r io.ReaderAt, size int64) (Bundle, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	var b Bundle
+
+	err = readFile(zr, FileUsers, []string{"sourcedId", "role", "givenName", "familyName"},
+		func(line int, get func(string) string) {
+			b.Users = append(b.Users, User{
+				Line:       line,
+				SourcedID:  get("sourcedId"),
+				Status:     get("status"),
+				Role:       get("role"),
+				Username:   get("username"),
+				GivenName:  get("givenName"),
+				FamilyName: get("familyName"),
+				Identifier: get("identifier"),
+				Enabled:    !strings.EqualFold(get("enabledUser"), "false"),
+			})
+		})
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	err = readFile(zr, FileClasses, []string{"sourcedId", "title"},
+		func(line int, get func(string) string) {
+			b.Classes = append(b.Classes, Class{
+				Line:      line,
+				SourcedID: get("sourcedId"),
+				Status:    get("status"),
+				Title:     get("title"),
+				ClassCode: get("classCode"),
+				CourseID:  get("courseSourcedId"),
+				SchoolID:  get("schoolSourcedId"),
+				TermIDs:   get("termSourcedIds"),
+			})
+		})
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	err = readFile(zr, FileEnrollments, []string{"sourcedId", "classSourcedId", "userSourcedId", "role"},
+		func(line int, get func(string) string) {
+			b.Enrollments = append(b.Enrollments, Enrollment{
+				Line:      line,
+				SourcedID: get("sourcedId"),
+				Status:    get("status"),
+				ClassID:   get("classSourcedId"),
+				SchoolID:  get("schoolSourcedId"),
+				UserID:    get("userSourcedId"),
+				Role:      get("role"),
+			})
+		})
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	return b, nil
+}
+
+// readFile calls fn for each row of the given csv file, get returns a column of the row by its name
+// or an empty string when the column does not exist.
+func readFile(zr *zip.Reader, name string, required []string, fn func(line int, get func(string) string)) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrMissingFile, name)
+	}
+	defer f.Close()
+
+	cr := csv.NewReader(f)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("cannot read %s header %w", name, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		// the first column may start with the utf-8 byte order mark.
+		columns[strings.TrimPrefix(strings.TrimSpace(h), "\ufeff")] = i
+	}
+
+	for _, c := range required {
+		if _, ok := columns[c]; !ok {
+			return fmt.Errorf("%w: %s in %s", ErrMissingColumn, c, name)
+		}
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("cannot read %s %w", name, err)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		fn(line, func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		})
+	}
+}
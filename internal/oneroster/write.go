@@ -0,0 +1,159 @@
+package oneroster
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// nolint: gochecknoglobals
+var (
+	orgsHeader = []string{
+		"sourcedId", "status", "dateLastModified", "name", "type", "identifier", "parentSourcedId",
+	}
+	sessionsHeader = []string{
+		"sourcedId", "status", "dateLastModified", "title", "type", "startDate", "endDate", "parentSourcedId",
+		"schoolYear",
+	}
+	coursesHeader = []string{
+		"sourcedId", "status", "dateLastModified", "schoolYearSourcedId", "title", "courseCode", "grades",
+		"orgSourcedId", "subjects", "subjectCodes",
+	}
+	classesHeader = []string{
+		"sourcedId", "status", "dateLastModified", "title", "grades", "courseSourcedId", "classCode", "classType",
+		"location", "schoolSourcedId", "termSourcedIds", "subjects", "subjectCodes", "periods",
+	}
+	usersHeader = []string{
+		"sourcedId", "status", "dateLastModified", "enabledUser", "orgSourcedIds", "role", "username", "userIds",
+		"givenName", "familyName", "middleName", "identifier", "email", "sms", "phone", "agentSourcedIds", "grades",
+		"password",
+	}
+	enrollmentsHeader = []string{
+		"sourcedId", "status", "dateLastModified", "classSourcedId", "schoolSourcedId", "userSourcedId", "role",
+		"primary", "beginDate", "endDate",
+	}
+)
+
+// Write writes the bundle as a OneRoster 1.1 bulk zip file. Status and dateLastModified
+// are left empty, as the specification requires for bulk files.
+func Write(w io.Writer, b Bundle) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name   string
+		header []string
+		rows   [][]string
+	}{
+		{FileManifest, []string{"propertyName", "value"}, manifest(b)},
+		{FileOrgs, orgsHeader, [][]string{{b.OrgID, "", "", b.OrgName, OrgTypeSchool, "", ""}}},
+		{FileAcademicSessions, sessionsHeader, [][]string{
+			{b.TermID, "", "", b.TermTitle, SessionTypeSchoolYear, b.TermStart, b.TermEnd, "", schoolYear(b.TermEnd)},
+		}},
+		{FileCourses, coursesHeader, courses(b)},
+		{FileClasses, classesHeader, classes(b)},
+		{FileUsers, usersHeader, users(b)},
+		{FileEnrollments, enrollmentsHeader, enrollments(b)},
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+
+		cw := csv.NewWriter(fw)
+
+		err = cw.Write(f.header)
+		if err != nil {
+			return err
+		}
+
+		err = cw.WriteAll(f.rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func manifest(b Bundle) [][]string {
+	return [][]string{
+		{"manifest.version", "1.0"},
+		{"oneroster.version", "1.1"},
+		{"file.academicSessions", "bulk"},
+		{"file.categories", "absent"},
+		{"file.classes", "bulk"},
+		{"file.classResources", "absent"},
+		{"file.courses", "bulk"},
+		{"file.courseResources", "absent"},
+		{"file.demographics", "absent"},
+		{"file.enrollments", "bulk"},
+		{"file.lineItems", "absent"},
+		{"file.orgs", "bulk"},
+		{"file.resources", "absent"},
+		{"file.results", "absent"},
+		{"file.users", "bulk"},
+		{"source.systemName", b.SystemName},
+		{"source.systemCode", ""},
+	}
+}
+
+// schoolYear returns the year of the given yyyy-mm-dd date.
+func schoolYear(date string) string {
+	year, _, _ := strings.Cut(date, "-")
+
+	return year
+}
+
+func courses(b Bundle) [][]string {
+	rows := make([][]string, 0, len(b.Classes))
+
+	for _, c := range b.Classes {
+		rows = append(rows, []string{c.CourseID, "", "", "", c.Title, c.ClassCode, "", b.OrgID, "", ""})
+	}
+
+	return rows
+}
+
+func classes(b Bundle) [][]string {
+	rows := make([][]string, 0, len(b.Classes))
+
+	for _, c := range b.Classes {
+		rows = append(rows, []string{
+			c.SourcedID, "", "", c.Title, "", c.CourseID, c.ClassCode, ClassTypeScheduled, "", b.OrgID, b.TermID,
+			"", "", "",
+		})
+	}
+
+	return rows
+}
+
+func users(b Bundle) [][]string {
+	rows := make([][]string, 0, len(b.Users))
+
+	for _, u := range b.Users {
+		enabled := "false"
+		if u.Enabled {
+			enabled = "true"
+		}
+
+		rows = append(rows, []string{
+			u.SourcedID, "", "", enabled, b.OrgID, u.Role, u.Username, "", u.GivenName, u.FamilyName, "",
+			u.Identifier, "", "", "", "", "", "",
+		})
+	}
+
+	return rows
+}
+
+func enrollments(b Bundle) [][]string {
+	rows := make([][]string, 0, len(b.Enrollments))
+
+	for _, e := range b.Enrollments {
+		rows = append(rows, []string{e.SourcedID, "", "", e.ClassID, b.OrgID, e.UserID, e.Role, "false", "", ""})
+	}
+
+	return rows
+}
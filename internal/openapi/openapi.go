@@ -0,0 +1,346 @@
+// Package openapi describes the REST API with an OpenAPI 3.1 document and validates the requests
+// and responses against it. The schemas are generated from the request and response types, so they
+// follow their json tags, and the operations are described next to each other in Spec.
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/response"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+)
+
+// Version is the version of the OpenAPI specification.
+const Version = "3.1.0"
+
+// Bearer is the name of the bearer token security scheme.
+const Bearer = "bearer"
+
+// IDPattern matches the student and course ids.
+const IDPattern = "^[0-9]{8}$"
+
+// schemas are the component schemas and the values which their schemas are generated from.
+// nolint: gochecknoglobals
+var schemas = map[string]any{
+	"Student":          response.Student{},
+	"StudentCreate":    request.StudentCreate{},
+	"Course":           model.Course{},
+	"CourseCreate":     request.CourseCreate{},
+	"Enrollment":       response.Enrollment{},
+	"EnrollmentCreate": request.EnrollmentCreate{},
+	"Meeting":          model.Meeting{},
+	"MeetingCreate":    request.Meeting{},
+	"Import":           response.Import{},
+	"Problem":          problem.Problem{},
+	"Login":            request.Login{},
+	"TokenRequest":     request.Token{},
+	"Token":            response.Token{},
+	"Principal":        auth.Principal{},
+}
+
+// required are the required properties of the component schemas.
+// nolint: gochecknoglobals
+var required = map[string][]string{
+	"StudentCreate":    {"name"},
+	"CourseCreate":     {"name"},
+	"EnrollmentCreate": {"course_id"},
+	"MeetingCreate":    {"weekday", "start", "end"},
+	"Problem":          {"type", "title", "status", "code"},
+	"Login":            {"username", "password"},
+	"TokenRequest":     {"token"},
+}
+
+// customize adds the enums of the model types and allows null for the arrays,
+// because the nil slices are encoded as null.
+func customize(_ string, t reflect.Type, _ reflect.StructTag, schema *openapi3.Schema) error {
+	if t == reflect.TypeFor[model.StudentStatus]() {
+		for _, s := range model.StudentStatuses() {
+			schema.Enum = append(schema.Enum, string(s))
+		}
+	}
+
+	if t == reflect.TypeFor[model.EnrollmentStatus]() {
+		for _, s := range model.EnrollmentStatuses() {
+			schema.Enum = append(schema.Enum, string(s))
+		}
+	}
+
+	if t == reflect.TypeFor[model.Role]() {
+		for _, r := range model.Roles() {
+			schema.Enum = append(schema.Enum, string(r))
+		}
+	}
+
+	if schema.Type.Is("array") {
+		schema.Type = &openapi3.Types{"array", "null"}
+	}
+
+	return nil
+}
+
+// Spec returns the OpenAPI document of the students and courses routes.
+func Spec() (*openapi3.T, error) {
+	doc := &openapi3.T{ // nolint: exhaustruct
+		OpenAPI: Version,
+		Info: &openapi3.Info{ // nolint: exhaustruct
+			Title:       "Students",
+			Description: "Students and their courses, errors are RFC 7807 problems.",
+			Version:     "1.0.0",
+		},
+		Components: &openapi3.Components{ // nolint: exhaustruct
+			Schemas: make(openapi3.Schemas),
+			SecuritySchemes: openapi3.SecuritySchemes{
+				Bearer: &openapi3.SecuritySchemeRef{ // nolint: exhaustruct
+					Value: openapi3.NewJWTSecurityScheme(),
+				},
+			},
+		},
+		// every operation needs an access token, unless it is public.
+		Security: *openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(Bearer)),
+		Paths:    openapi3.NewPaths(),
+	}
+
+	for name, v := range schemas {
+		ref, err := openapi3gen.NewSchemaRefForValue(v, nil, openapi3gen.SchemaCustomizer(customize))
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate %s schema %w", name, err)
+		}
+
+		ref.Value.Required = required[name]
+		doc.Components.Schemas[name] = openapi3.NewSchemaRef("", ref.Value)
+	}
+
+	authentication(doc)
+	students(doc)
+	enrollments(doc)
+	courses(doc)
+
+	// the operations only have the names of their schemas.
+	err := openapi3.NewLoader().ResolveRefsIn(doc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve openapi references %w", err)
+	}
+
+	err = doc.Validate(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("invalid openapi document %w", err)
+	}
+
+	return doc, nil
+}
+
+func authentication(doc *openapi3.T) {
+	token := respond(http.StatusOK, "access and refresh tokens", schema("Token"))
+
+	doc.AddOperation("/v1/auth/login", http.MethodPost, public(operation("login",
+		"issues the tokens of the user with the correct password", nil, jsonBody(schema("Login")), token)))
+	doc.AddOperation("/v1/auth/refresh", http.MethodPost, public(operation("refresh",
+		"exchanges a refresh token for new tokens, the refresh token is revoked", nil,
+		jsonBody(schema("TokenRequest")), token)))
+	doc.AddOperation("/v1/auth/revoke", http.MethodPost, public(operation("revoke",
+		"revokes an access or refresh token", nil, jsonBody(schema("TokenRequest")),
+		respond(http.StatusNoContent, "revoked", nil))))
+	doc.AddOperation("/v1/auth/me", http.MethodGet, operation("me", "returns the authenticated user", nil, nil,
+		respond(http.StatusOK, "authenticated user", schema("Principal"))))
+}
+
+// public removes the security requirement of the operation.
+func public(op *openapi3.Operation) *openapi3.Operation {
+	op.Security = openapi3.NewSecurityRequirements()
+
+	return op
+}
+
+// deprecated marks the operation which has a successor.
+func deprecated(op *openapi3.Operation) *openapi3.Operation {
+	op.Deprecated = true
+
+	return op
+}
+
+func students(doc *openapi3.T) {
+	student := schema("Student")
+
+	doc.AddOperation("/v1/students", http.MethodPost, operation("createStudent", "creates a student",
+		params(calendar()), jsonBody(schema("StudentCreate")),
+		respond(http.StatusCreated, "created student", student)))
+	doc.AddOperation("/v1/students:import", http.MethodPost, operation("importStudents", "imports students from a csv file",
+		params(calendar(), dryRun(), atomic()), csvBody(), imported()...))
+	doc.AddOperation("/v1/students", http.MethodGet, operation("listStudents", "lists the students",
+		params(calendar()), nil,
+		respond(http.StatusOK, "students", array(student))))
+	doc.AddOperation("/v1/students/{id}", http.MethodGet, operation("getStudent", "returns a student with its courses",
+		params(path("id"), calendar()), nil,
+		respond(http.StatusOK, "student", student)))
+	doc.AddOperation("/v1/students/{sid}/register/{cid}", http.MethodGet, deprecated(operation("registerStudent",
+		"registers the student into the course, use createEnrollment", params(path("sid"), path("cid")), nil,
+		respond(http.StatusOK, "registered", openapi3.NewSchemaRef("", &openapi3.Schema{ // nolint: exhaustruct
+			Type: &openapi3.Types{"null"},
+		})))))
+	doc.AddOperation("/v1/students/{sid}/register/{cid}", http.MethodDelete, deprecated(operation("dropStudent",
+		"drops the student from the course, use deleteEnrollment", params(path("sid"), path("cid")), nil,
+		respond(http.StatusNoContent, "dropped", nil))))
+}
+
+func enrollments(doc *openapi3.T) {
+	enrollment := schema("Enrollment")
+
+	doc.AddOperation("/v1/students/{sid}/enrollments", http.MethodPost, operation("createEnrollment",
+		"enrolls the student into the course, an existing enrollment is returned with 200",
+		params(path("sid"), calendar()), jsonBody(schema("EnrollmentCreate")),
+		respond(http.StatusCreated, "created enrollment", enrollment),
+		respond(http.StatusOK, "existing enrollment", enrollment)))
+	doc.AddOperation("/v1/students/{sid}/enrollments", http.MethodGet, operation("listEnrollments",
+		"lists the enrollments of the student", params(path("sid"), calendar()), nil,
+		respond(http.StatusOK, "enrollments", array(enrollment))))
+	doc.AddOperation("/v1/students/{sid}/enrollments/{cid}", http.MethodGet, operation("getEnrollment",
+		"returns an enrollment of the student", params(path("sid"), path("cid"), calendar()), nil,
+		respond(http.StatusOK, "enrollment", enrollment)))
+	doc.AddOperation("/v1/students/{sid}/enrollments/{cid}", http.MethodDelete, operation("deleteEnrollment",
+		"drops the student from the course", params(path("sid"), path("cid"), calendar()), nil,
+		respond(http.StatusOK, "dropped enrollment", enrollment)))
+}
+
+func courses(doc *openapi3.T) {
+	course := schema("Course")
+	meetings := array(schema("Meeting"))
+
+	doc.AddOperation("/v1/courses", http.MethodPost, operation("createCourse", "creates a course",
+		nil, jsonBody(schema("CourseCreate")),
+		respond(http.StatusCreated, "created course", course)))
+	doc.AddOperation("/v1/courses:import", http.MethodPost, operation("importCourses", "imports courses from a csv file",
+		params(dryRun(), atomic()), csvBody(), imported()...))
+	doc.AddOperation("/v1/courses", http.MethodGet, operation("listCourses", "lists the courses",
+		nil, nil,
+		respond(http.StatusOK, "courses", array(course))))
+	doc.AddOperation("/v1/courses/{id}", http.MethodGet, operation("getCourse", "returns a course",
+		params(path("id")), nil,
+		respond(http.StatusOK, "course", course)))
+	doc.AddOperation("/v1/courses/{id}/meetings", http.MethodGet, operation("getMeetings",
+		"returns the weekly meetings of the course", params(path("id")), nil,
+		respond(http.StatusOK, "meetings", meetings)))
+	doc.AddOperation("/v1/courses/{id}/meetings", http.MethodPut, operation("setMeetings",
+		"replaces the weekly meetings of the course", params(path("id")), jsonBody(array(schema("MeetingCreate"))),
+		respond(http.StatusOK, "meetings", meetings)))
+}
+
+func schema(name string) *openapi3.SchemaRef {
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, nil)
+}
+
+func array(items *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return openapi3.NewSchemaRef("", &openapi3.Schema{ // nolint: exhaustruct
+		Type:  &openapi3.Types{"array"},
+		Items: items,
+	})
+}
+
+func params(ps ...*openapi3.Parameter) openapi3.Parameters {
+	refs := make(openapi3.Parameters, 0, len(ps))
+	for _, p := range ps {
+		refs = append(refs, &openapi3.ParameterRef{Ref: "", Value: p}) // nolint: exhaustruct
+	}
+
+	return refs
+}
+
+func path(name string) *openapi3.Parameter {
+	return openapi3.NewPathParameter(name).WithSchema(&openapi3.Schema{ // nolint: exhaustruct
+		Type:    &openapi3.Types{"string"},
+		Pattern: IDPattern,
+	})
+}
+
+func query(name string, description string, s *openapi3.Schema) *openapi3.Parameter {
+	return openapi3.NewQueryParameter(name).WithDescription(description).WithSchema(s)
+}
+
+func calendar() *openapi3.Parameter {
+	return query("calendar", "calendar of the rendered dates and the birth date", &openapi3.Schema{ // nolint: exhaustruct
+		Type: &openapi3.Types{"string"},
+		Enum: []any{"gregorian", "jalali"},
+	})
+}
+
+func dryRun() *openapi3.Parameter {
+	return query("dry_run", "only validates the file", openapi3.NewBoolSchema())
+}
+
+func atomic() *openapi3.Parameter {
+	return query("atomic", "creates all the rows or none of them, it is true by default", openapi3.NewBoolSchema())
+}
+
+func jsonBody(s *openapi3.SchemaRef) *openapi3.RequestBodyRef {
+	return &openapi3.RequestBodyRef{ // nolint: exhaustruct
+		Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(s),
+	}
+}
+
+// csvBody is the csv file of the bulk imports, it is either the request body or the file field of a form.
+func csvBody() *openapi3.RequestBodyRef {
+	form := openapi3.NewObjectSchema().WithProperty("file", openapi3.NewStringSchema().WithFormat("binary"))
+
+	body := openapi3.NewRequestBody().WithRequired(true).WithContent(openapi3.Content{
+		"text/csv":            openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema()),
+		"multipart/form-data": openapi3.NewMediaType().WithSchema(form),
+	})
+
+	return &openapi3.RequestBodyRef{Value: body} // nolint: exhaustruct
+}
+
+type status struct {
+	code        int
+	description string
+	schema      *openapi3.SchemaRef
+}
+
+func respond(code int, description string, s *openapi3.SchemaRef) status {
+	return status{code: code, description: description, schema: s}
+}
+
+// imported are the responses of the bulk imports, the report is returned for any outcome.
+func imported() []status {
+	return []status{
+		respond(http.StatusOK, "dry run or non-atomic import report", schema("Import")),
+		respond(http.StatusCreated, "atomic import report", schema("Import")),
+		respond(http.StatusUnprocessableEntity, "report of the rejected file", schema("Import")),
+	}
+}
+
+// operation describes an operation, its errors are problems.
+func operation(
+	id string,
+	summary string,
+	ps openapi3.Parameters,
+	body *openapi3.RequestBodyRef,
+	statuses ...status,
+) *openapi3.Operation {
+	op := openapi3.NewOperation()
+	op.OperationID = id
+	op.Summary = summary
+	op.Parameters = ps
+	op.RequestBody = body
+
+	for _, s := range statuses {
+		r := openapi3.NewResponse().WithDescription(s.description)
+		if s.schema != nil {
+			r = r.WithJSONSchemaRef(s.schema)
+		}
+
+		op.AddResponse(s.code, r)
+	}
+
+	problems := openapi3.NewResponse().WithDescription("problem").
+		WithContent(openapi3.NewContentWithSchemaRef(schema("Problem"), []string{problem.ContentType}))
+	op.Responses.Set("default", &openapi3.ResponseRef{Value: problems}) // nolint: exhaustruct
+
+	return op
+}
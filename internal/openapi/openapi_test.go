@@ -0,0 +1,186 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/handler"
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/openapi"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/labstack/echo/v4"
+)
+
+// TestSpec_Routes fails when a route of the handlers is not documented and is not undocumented on purpose.
+func TestSpec_Routes(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := echo.New()
+
+	g := app.Group("/v1")
+	handler.Auth{Users: nil, Tokens: auth.Tokens{}, Revoked: nil}.Register(g)
+	handler.APIKey{Store: nil}.Register(g)
+	handler.Student{Store: nil, Location: nil}.Register(g)
+	handler.Enrollment{Store: nil, Location: nil}.Register(g)
+	handler.OpenAPI{Spec: nil}.Register(g)
+	handler.Course{Store: nil}.Register(g)
+	handler.Export{Store: nil, Courses: nil}.Register(g)
+	handler.OneRoster{Students: nil, Courses: nil, SourcedIDs: nil, University: "", Location: nil}.Register(g)
+	handler.Schedule{Students: nil, Courses: nil, Term: model.Term{}, Location: nil}.Register(g)
+	handler.Stats{Store: nil}.Register(g)
+	handler.Webhook{Store: nil}.Register(g)
+	handler.Events{Broker: nil, Heartbeat: 0}.Register(g)
+	handler.Admin{Backup: nil}.Register(app.Group("/admin"))
+
+	// the routes which are not in the document on purpose, the document describes the json api of the students,
+	// their enrollments and the courses.
+	undocumented := map[string]bool{
+		// the document and its viewer.
+		"GET /v1/openapi.json": true,
+		"GET /v1/docs":         true,
+		// the files and the streams which are not json.
+		"GET /v1/courses/:id/students":      true,
+		"GET /v1/export/enrollments":        true,
+		"GET /v1/oneroster/export":          true,
+		"POST /v1/oneroster/import":         true,
+		"GET /v1/students/:id/schedule.ics": true,
+		"GET /v1/events":                    true,
+		// the operator routes.
+		"POST /v1/api-keys":                            true,
+		"GET /v1/api-keys":                             true,
+		"GET /v1/api-keys/:id":                         true,
+		"POST /v1/api-keys/:id/rotate":                 true,
+		"DELETE /v1/api-keys/:id":                      true,
+		"POST /v1/webhooks":                            true,
+		"GET /v1/webhooks":                             true,
+		"GET /v1/webhooks/:id":                         true,
+		"PUT /v1/webhooks/:id":                         true,
+		"DELETE /v1/webhooks/:id":                      true,
+		"GET /v1/webhooks/:id/deliveries":              true,
+		"POST /v1/webhooks/:id/deliveries/:did/replay": true,
+		"GET /v1/stats":                                true,
+		"POST /admin/backup":                           true,
+	}
+
+	params := regexp.MustCompile(`:(\w+)`)
+
+	for _, r := range app.Routes() {
+		path := params.ReplaceAllString(strings.ReplaceAll(r.Path, `\:`, "\x00"), "{$1}")
+		path = strings.ReplaceAll(path, "\x00", ":")
+
+		if _, ok := undocumented[r.Method+" "+r.Path]; ok {
+			undocumented[r.Method+" "+r.Path] = false
+
+			continue
+		}
+
+		item := doc.Paths.Value(path)
+		if item == nil || item.GetOperation(r.Method) == nil {
+			t.Errorf("%s %s does not have an openapi operation", r.Method, path)
+		}
+	}
+
+	// the routes which are removed must be removed from the list too.
+	for route, missing := range undocumented {
+		if missing {
+			t.Errorf("%s is not registered", route)
+		}
+	}
+}
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := openapi.NewValidator(doc, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(i18n.Middleware())
+	app.Use(v.Middleware())
+
+	app.POST("/v1/students", func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, map[string]string{"id": "12345678", "name": "Ali Alavi"})
+	})
+	app.GET("/v1/courses/:id", func(c echo.Context) error {
+		// capacity must be an integer.
+		return c.JSON(http.StatusOK, map[string]string{"id": c.Param("id"), "capacity": "many"})
+	})
+	app.PUT("/v1/courses/:id/meetings", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   problem.Code
+		field  string
+	}{
+		{"valid", http.MethodPost, "/v1/students", `{"name": "Ali Alavi"}`, 201, "", ""},
+		{"required", http.MethodPost, "/v1/students", `{"email": "ali@aut.ac.ir"}`, 400, problem.CodeValidationFailed, "name"},
+		{"type", http.MethodPost, "/v1/students", `{"name": "Ali Alavi", "birth_date": 1}`, 400, problem.CodeValidationFailed, "birth_date"},
+		{"shape", http.MethodPost, "/v1/students", `["Ali Alavi"]`, 400, problem.CodeMalformedBody, ""},
+		{"enum", http.MethodGet, "/v1/students?calendar=lunar", "", 400, problem.CodeInvalidParameter, "calendar"},
+		{"pattern", http.MethodGet, "/v1/courses/ce40", "", 400, problem.CodeInvalidParameter, "id"},
+		// the first one of the invalid parameters by name.
+		{"parameters", http.MethodGet, "/v1/students/ce40?calendar=lunar", "", 400, problem.CodeInvalidParameter, "calendar"},
+		{"nested", http.MethodPut, "/v1/courses/12345678/meetings", `[{"weekday": "saturday", "start": "10:00"}]`, 400, problem.CodeValidationFailed, "0.end"},
+		{"response", http.MethodGet, "/v1/courses/12345678", "", 500, problem.CodeInternal, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, w.Code, w.Body)
+			}
+
+			if tc.code == "" {
+				return
+			}
+
+			var p problem.Problem
+
+			err := json.NewDecoder(w.Body).Decode(&p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if p.Code != tc.code {
+				t.Errorf("expected %s problem, got %+v", tc.code, p)
+			}
+
+			if tc.field != "" && p.Errors[tc.field] == "" {
+				t.Errorf("expected an error for %s, got %v", tc.field, p.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrSchema is the message of the schema violations which are not an ozzo-validation rule.
+// nolint: gochecknoglobals
+var ErrSchema = validation.NewError("validation_schema", "does not match the schema")
+
+// Validator validates the requests and responses of the documented routes, the other routes are not validated.
+// Only json bodies are validated, the others (e.g. csv files) are validated by their handlers.
+// Invalid responses are logged and they are only rejected when it is strict.
+type Validator struct {
+	Router routers.Router
+	Strict bool
+}
+
+func NewValidator(doc *openapi3.T, strict bool) (Validator, error) {
+	r, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return Validator{}, fmt.Errorf("cannot create the openapi router %w", err)
+	}
+
+	return Validator{
+		Router: r,
+		Strict: strict,
+	}, nil
+}
+
+func isJSON(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mt == echo.MIMEApplicationJSON || strings.HasSuffix(mt, "+json")
+}
+
+// recorder keeps the response until it is validated.
+type recorder struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.body.Write(b)
+}
+
+// Flush is a no-op, because the response is written after its validation.
+func (r *recorder) Flush() {}
+
+func (v Validator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route, params, err := v.Router.FindRoute(req)
+			if err != nil {
+				return next(c)
+			}
+
+			input := &openapi3filter.RequestValidationInput{ // nolint: exhaustruct
+				Request:    req,
+				PathParams: params,
+				Route:      route,
+				Options: &openapi3filter.Options{ // nolint: exhaustruct
+					ExcludeRequestBody: !isJSON(req.Header.Get(echo.HeaderContentType)),
+					MultiError:         true,
+					// the tokens are verified by the auth middleware.
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+
+			err = openapi3filter.ValidateRequest(req.Context(), input)
+			if err != nil {
+				return requestProblem(err)
+			}
+
+			w := c.Response().Writer
+			rec := &recorder{ResponseWriter: w, status: 0, body: bytes.Buffer{}}
+			c.Response().Writer = rec
+
+			err = next(c)
+
+			c.Response().Writer = w
+
+			if err == nil && rec.status != 0 {
+				rerr := v.response(c, input, rec)
+				if rerr != nil {
+					return rerr
+				}
+			}
+
+			if rec.status != 0 {
+				w.WriteHeader(rec.status)
+
+				_, werr := w.Write(rec.body.Bytes())
+				if werr != nil {
+					log.Println(werr)
+				}
+			}
+
+			return err
+		}
+	}
+}
+
+// response validates the recorded response, in the strict mode the response is replaced with a problem.
+func (v Validator) response(c echo.Context, input *openapi3filter.RequestValidationInput, rec *recorder) error {
+	header := c.Response().Header()
+
+	err := openapi3filter.ValidateResponse(c.Request().Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+		Options: &openapi3filter.Options{ // nolint: exhaustruct
+			ExcludeResponseBody:   !isJSON(header.Get(echo.HeaderContentType)),
+			IncludeResponseStatus: true,
+		},
+	})
+	if err == nil {
+		return nil
+	}
+
+	log.Printf("response of %s %s does not match the openapi document: %s", input.Request.Method, input.Route.Path, err)
+
+	if !v.Strict {
+		return nil
+	}
+
+	// the response is replaced by the problem, so its headers must not leak.
+	header.Del(echo.HeaderContentType)
+	header.Del(echo.HeaderContentLength)
+	c.Response().Committed = false
+
+	return problem.Internal(err)
+}
+
+// requestProblem reports the request violations per parameter or body field, the schema keywords which
+// have an ozzo-validation counterpart use its message so they can be localized.
+func requestProblem(err error) error {
+	fields := make(validation.Errors)
+	params := make([]string, 0)
+
+	var me openapi3.MultiError
+	if !errors.As(err, &me) {
+		me = openapi3.MultiError{err}
+	}
+
+	for _, e := range me {
+		var re *openapi3filter.RequestError
+		if !errors.As(e, &re) {
+			return problem.Bind(e)
+		}
+
+		var se *openapi3.SchemaError
+
+		switch {
+		case re.Parameter != nil:
+			params = append(params, re.Parameter.Name)
+			fields[re.Parameter.Name] = ErrSchema
+
+			if errors.As(re.Err, &se) {
+				for _, v := range violations(se) {
+					fields[re.Parameter.Name] = v.err
+				}
+			}
+		case re.RequestBody != nil && errors.As(re.Err, &se):
+			for _, v := range violations(se) {
+				// the body itself has the wrong shape.
+				if v.field == "" {
+					return problem.Bind(e)
+				}
+
+				fields[v.field] = v.err
+			}
+		default:
+			return problem.Bind(e)
+		}
+	}
+
+	// a problem has a single parameter, the first one by name is reported so it is the same on each request.
+	if len(params) > 0 {
+		name := slices.Min(params)
+
+		return problem.Param(name, fields[name])
+	}
+
+	return problem.Validation(fields)
+}
+
+// reason matches the json schema 2020 errors, e.g. at '/0': missing property 'end'.
+// nolint: gochecknoglobals
+var reason = regexp.MustCompile(`(?s)at '([^']*)': (.*)$`)
+
+// missing matches the names of the missing required properties.
+// nolint: gochecknoglobals
+var missing = regexp.MustCompile(`'([^']*)'`)
+
+type violation struct {
+	field string
+	err   error
+}
+
+// violations returns the invalid fields of a schema error with their dotted paths,
+// a missing required property is reported on itself.
+func violations(se *openapi3.SchemaError) []violation {
+	var causes openapi3.MultiError
+	if errors.As(se.Origin, &causes) {
+		vs := make([]violation, 0, len(causes))
+
+		for _, c := range causes {
+			var cse *openapi3.SchemaError
+			if errors.As(c, &cse) {
+				vs = append(vs, violations(cse)...)
+			}
+		}
+
+		return vs
+	}
+
+	// the errors of the built-in validator have their keyword and location,
+	// it is used when a schema cannot be compiled as a json schema 2020.
+	if se.SchemaField != "" {
+		return []violation{{field: strings.Join(se.JSONPointer(), "."), err: keyword(se.SchemaField)}}
+	}
+
+	return parse(se.Reason)
+}
+
+// parse parses the text of the json schema 2020 errors, kin-openapi does not keep their location and keyword.
+func parse(text string) []violation {
+	m := reason.FindStringSubmatch(text)
+	if m == nil {
+		return []violation{{field: "", err: ErrSchema}}
+	}
+
+	var path []string
+	if m[1] != "" {
+		path = strings.Split(strings.TrimPrefix(m[1], "/"), "/")
+		for i := range path {
+			path[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(path[i])
+		}
+	}
+
+	msg := m[2]
+
+	switch {
+	case strings.HasPrefix(msg, "missing propert"):
+		names := missing.FindAllStringSubmatch(msg, -1)
+		vs := make([]violation, 0, len(names))
+
+		for _, n := range names {
+			vs = append(vs, violation{field: strings.Join(append(slices.Clone(path), n[1]), "."), err: keyword("required")})
+		}
+
+		return vs
+	case strings.Contains(msg, "does not match pattern"):
+		return []violation{{field: strings.Join(path, "."), err: keyword("pattern")}}
+	case strings.HasPrefix(msg, "value must be"):
+		return []violation{{field: strings.Join(path, "."), err: keyword("enum")}}
+	default:
+		return []violation{{field: strings.Join(path, "."), err: ErrSchema}}
+	}
+}
+
+// keyword returns the ozzo-validation error of a schema keyword.
+func keyword(name string) error {
+	switch name {
+	case "required":
+		return validation.ErrRequired
+	case "enum", "const":
+		return validation.ErrInInvalid
+	case "pattern":
+		return validation.ErrMatchInvalid
+	default:
+		return ErrSchema
+	}
+}
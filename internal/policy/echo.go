@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/labstack/echo/v4"
+)
+
+// Problem reports the errors of Check as problems.
+func Problem(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, err.Error())
+	case errors.Is(err, ErrForbidden):
+		return problem.New(http.StatusForbidden, problem.CodeForbidden, err.Error())
+	default:
+		return err
+	}
+}
+
+// Of returns the resource of a request.
+type Of func(c echo.Context) Resource
+
+// None is the resource of the requests which are not performed on a particular resource.
+func None(echo.Context) Resource {
+	return Any
+}
+
+// StudentParam returns the resource of the student in the given path parameter.
+func StudentParam(name string) Of {
+	return func(c echo.Context) Resource {
+		return Student(c.Param(name))
+	}
+}
+
+// Require is a route middleware which rejects the requests which cannot perform the action on their resource.
+// The actions on the resources which are loaded by the handler (e.g. the courses) are checked in the handler.
+func Require(a Action, of Of) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := Check(c.Request().Context(), a, of(c))
+			if err != nil {
+				return Problem(err)
+			}
+
+			return next(c)
+		}
+	}
+}
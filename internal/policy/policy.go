@@ -0,0 +1,132 @@
+// Package policy decides what the authenticated principals are allowed to do. Admins can do everything,
+// the other roles can only perform their actions and on their own resources: students read and register
+// themselves and instructors see the rosters of their own courses. API keys don't have a role, they can
+// perform the actions of their scopes on any resource.
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+var ErrForbidden = errors.New("permission denied")
+
+// Action is what a principal does, e.g. reading a student.
+type Action string
+
+const (
+	ReadStudents      Action = "students:read"
+	ListStudents      Action = "students:list"
+	WriteStudents     Action = "students:write"
+	WriteEnrollments  Action = "enrollments:write"
+	ExportEnrollments Action = "enrollments:export"
+	ReadCourses       Action = "courses:read"
+	WriteCourses      Action = "courses:write"
+	ReadRosters       Action = "rosters:read"
+	ReadStats         Action = "stats:read"
+	ReadEvents        Action = "events:read"
+	ManageWebhooks    Action = "webhooks:manage"
+	SyncOneRoster     Action = "oneroster:sync"
+	CreateBackups     Action = "backups:create"
+	ManageAPIKeys     Action = "apikeys:manage"
+	// ReadPII is reading the personally identifiable information of a student.
+	ReadPII Action = "pii:read"
+)
+
+// Actions returns the actions which can be granted to the api keys, managing the api keys is not
+// one of them so a key cannot create a key with more scopes, and the keys never read PII.
+func Actions() []Action {
+	return []Action{
+		ReadStudents, ListStudents, WriteStudents, WriteEnrollments, ExportEnrollments, ReadCourses,
+		WriteCourses, ReadRosters, ReadStats, ReadEvents, ManageWebhooks, SyncOneRoster, CreateBackups,
+	}
+}
+
+// roles are the roles other than admin which can perform each action, the actions
+// which are not listed are only performed by the admins.
+// nolint: gochecknoglobals
+var roles = map[Action][]model.Role{
+	ReadStudents:     {model.RoleStudent},
+	WriteEnrollments: {model.RoleStudent},
+	ReadCourses:      {model.RoleStudent, model.RoleInstructor},
+	ReadRosters:      {model.RoleInstructor},
+	ReadPII:          {model.RoleStudent},
+}
+
+// Kind is the kind of the resources which have an owner.
+type Kind int
+
+const (
+	// KindNone is the kind of the actions which are not performed on a particular resource.
+	KindNone Kind = iota
+	// KindStudent is owned by the user of the student.
+	KindStudent
+	// KindCourse is owned by its instructor.
+	KindCourse
+)
+
+// Resource is what an action is performed on.
+type Resource struct {
+	Kind Kind
+	// Owner is the student id of the students and the instructor username of the courses.
+	Owner string
+}
+
+// Any is the resource of the actions which are not performed on a particular resource.
+// nolint: gochecknoglobals
+var Any = Resource{Kind: KindNone, Owner: ""}
+
+// Student returns the resource of the student with the given id.
+func Student(id string) Resource {
+	return Resource{Kind: KindStudent, Owner: id}
+}
+
+// Course returns the resource of a course with the given instructor.
+func Course(instructor string) Resource {
+	return Resource{Kind: KindCourse, Owner: instructor}
+}
+
+// owns reports whether the resource belongs to the principal.
+func owns(p auth.Principal, r Resource) bool {
+	switch r.Kind {
+	case KindNone:
+		return true
+	case KindStudent:
+		return p.Role == model.RoleStudent && p.StudentID != "" && p.StudentID == r.Owner
+	case KindCourse:
+		return p.Role == model.RoleInstructor && p.Username != "" && p.Username == r.Owner
+	default:
+		return false
+	}
+}
+
+// Allowed reports whether the principal can perform the action on the resource.
+func Allowed(p auth.Principal, a Action, r Resource) bool {
+	if p.Scoped() {
+		return a != ManageAPIKeys && slices.Contains(p.Scopes, string(a))
+	}
+
+	if p.Role == model.RoleAdmin {
+		return true
+	}
+
+	return slices.Contains(roles[a], p.Role) && owns(p, r)
+}
+
+// Check returns nil when the principal of the context can perform the action on the resource.
+func Check(ctx context.Context, a Action, r Resource) error {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return auth.ErrUnauthenticated
+	}
+
+	if !Allowed(p, a, r) {
+		return ErrForbidden
+	}
+
+	return nil
+}
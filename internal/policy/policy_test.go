@@ -0,0 +1,149 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/auth"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/policy"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/labstack/echo/v4"
+)
+
+// nolint: gochecknoglobals
+var (
+	admin      = auth.Principal{Username: "root", Role: model.RoleAdmin, StudentID: "", KeyID: "", Scopes: nil}
+	instructor = auth.Principal{Username: "bahador", Role: model.RoleInstructor, StudentID: "", KeyID: "", Scopes: nil}
+	student    = auth.Principal{Username: "elahe", Role: model.RoleStudent, StudentID: "12345678", KeyID: "", Scopes: nil}
+	// unlinked is a student user which is not linked to a student.
+	unlinked = auth.Principal{Username: "parham", Role: model.RoleStudent, StudentID: "", KeyID: "", Scopes: nil}
+	key      = auth.Principal{
+		Username: "sync", Role: "", StudentID: "", KeyID: "0123456789ab", Scopes: []string{"students:read", "rosters:read"},
+	}
+	// unscoped is an api key without any scope.
+	unscoped = auth.Principal{Username: "sync", Role: "", StudentID: "", KeyID: "0123456789ab", Scopes: []string{}}
+)
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		principal auth.Principal
+		action    policy.Action
+		resource  policy.Resource
+		allowed   bool
+	}{
+		// admins do everything.
+		{"admin reads a student", admin, policy.ReadStudents, policy.Student("12345678"), true},
+		{"admin lists students", admin, policy.ListStudents, policy.Any, true},
+		{"admin creates students", admin, policy.WriteStudents, policy.Any, true},
+		{"admin registers a student", admin, policy.WriteEnrollments, policy.Student("12345678"), true},
+		{"admin reads a roster", admin, policy.ReadRosters, policy.Course("bahador"), true},
+		{"admin reads a roster without instructor", admin, policy.ReadRosters, policy.Course(""), true},
+		{"admin creates courses", admin, policy.WriteCourses, policy.Any, true},
+		{"admin manages webhooks", admin, policy.ManageWebhooks, policy.Any, true},
+		{"admin creates backups", admin, policy.CreateBackups, policy.Any, true},
+		{"admin reads pii", admin, policy.ReadPII, policy.Student("12345678"), true},
+
+		// students read and register themselves.
+		{"student reads itself", student, policy.ReadStudents, policy.Student("12345678"), true},
+		{"student reads another", student, policy.ReadStudents, policy.Student("87654321"), false},
+		{"student registers itself", student, policy.WriteEnrollments, policy.Student("12345678"), true},
+		{"student registers another", student, policy.WriteEnrollments, policy.Student("87654321"), false},
+		{"student reads courses", student, policy.ReadCourses, policy.Any, true},
+		{"student lists students", student, policy.ListStudents, policy.Any, false},
+		{"student creates students", student, policy.WriteStudents, policy.Any, false},
+		{"student creates courses", student, policy.WriteCourses, policy.Any, false},
+		{"student reads a roster", student, policy.ReadRosters, policy.Course("elahe"), false},
+		{"student reads stats", student, policy.ReadStats, policy.Any, false},
+		{"student reads its pii", student, policy.ReadPII, policy.Student("12345678"), true},
+		{"student reads pii of another", student, policy.ReadPII, policy.Student("87654321"), false},
+		{"unlinked student reads an empty student", unlinked, policy.ReadStudents, policy.Student(""), false},
+
+		// instructors see their own course rosters.
+		{"instructor reads its roster", instructor, policy.ReadRosters, policy.Course("bahador"), true},
+		{"instructor reads another roster", instructor, policy.ReadRosters, policy.Course("ahmad"), false},
+		{"instructor reads a roster without instructor", instructor, policy.ReadRosters, policy.Course(""), false},
+		{"instructor reads courses", instructor, policy.ReadCourses, policy.Any, true},
+		{"instructor reads a student", instructor, policy.ReadStudents, policy.Student("12345678"), false},
+		{"instructor registers a student", instructor, policy.WriteEnrollments, policy.Student("12345678"), false},
+		{"instructor lists students", instructor, policy.ListStudents, policy.Any, false},
+		{"instructor creates courses", instructor, policy.WriteCourses, policy.Any, false},
+		{"instructor exports enrollments", instructor, policy.ExportEnrollments, policy.Any, false},
+		{"instructor reads pii", instructor, policy.ReadPII, policy.Student("12345678"), false},
+
+		// api keys perform their scopes on any resource.
+		{"key reads a student", key, policy.ReadStudents, policy.Student("12345678"), true},
+		{"key reads a roster", key, policy.ReadRosters, policy.Course("bahador"), true},
+		{"key registers a student", key, policy.WriteEnrollments, policy.Student("12345678"), false},
+		{"key lists students", key, policy.ListStudents, policy.Any, false},
+		{"key manages api keys", key, policy.ManageAPIKeys, policy.Any, false},
+		{"key reads pii", key, policy.ReadPII, policy.Student("12345678"), false},
+		{"unscoped key reads courses", unscoped, policy.ReadCourses, policy.Any, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if allowed := policy.Allowed(tc.principal, tc.action, tc.resource); allowed != tc.allowed {
+				t.Errorf("expected %t, got %t", tc.allowed, allowed)
+			}
+		})
+	}
+}
+
+func TestCheck_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	err := policy.Check(context.Background(), policy.ReadCourses, policy.Any)
+	if !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Errorf("expected unauthenticated, got %v", err)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.GET("/students/:sid/register/:cid", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, policy.Require(policy.WriteEnrollments, policy.StudentParam("sid")))
+
+	cases := []struct {
+		name      string
+		principal *auth.Principal
+		sid       string
+		status    int
+	}{
+		{"itself", &student, "12345678", http.StatusNoContent},
+		{"another", &student, "87654321", http.StatusForbidden},
+		{"admin", &admin, "87654321", http.StatusNoContent},
+		{"instructor", &instructor, "12345678", http.StatusForbidden},
+		{"anonymous", nil, "12345678", http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/students/"+tc.sid+"/register/10101010", nil)
+			if tc.principal != nil {
+				r = r.WithContext(auth.WithPrincipal(r.Context(), *tc.principal))
+			}
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Errorf("expected status %d, got %d: %s", tc.status, w.Code, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+// Package privacy decides who can see the personally identifiable information (PII) of the students,
+// e.g. their email, phone, national code and birth date. PII is hidden unless the principal of the request
+// can read the PII of the student through the policy, i.e. the admins and the student itself.
+package privacy
+
+import (
+	"context"
+
+	"github.com/1995parham-teaching/students/internal/policy"
+)
+
+// PII reports whether the principal of the context can see the PII of the given student.
+func PII(ctx context.Context, sid string) bool {
+	return policy.Check(ctx, policy.ReadPII, policy.Student(sid)) == nil
+}
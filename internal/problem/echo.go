@@ -0,0 +1,92 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/labstack/echo/v4"
+)
+
+// Bind reports the errors of echo binder, e.g. a malformed json body or an unsupported content type.
+func Bind(err error) *Problem {
+	var he *echo.HTTPError
+	if !errors.As(err, &he) {
+		p := New(http.StatusBadRequest, CodeMalformedBody, "request body is malformed")
+		p.Err = err
+
+		return p
+	}
+
+	code := CodeMalformedBody
+	if he.Code != http.StatusBadRequest {
+		code = CodeOf(he.Code)
+	}
+
+	p := New(he.Code, code, message(he))
+	p.Err = err
+
+	return p
+}
+
+// message returns the message of an echo error when it says more than its status.
+func message(he *echo.HTTPError) string {
+	msg := fmt.Sprint(he.Message)
+	if msg == http.StatusText(he.Code) {
+		return ""
+	}
+
+	return msg
+}
+
+// from converts any error into a problem, the echo errors keep their status and message.
+func from(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		p := New(he.Code, CodeOf(he.Code), message(he))
+		p.Err = he.Internal
+
+		return p
+	}
+
+	return Internal(err)
+}
+
+// Status returns the status code which the error is written with.
+func Status(err error) int {
+	return from(err).Status
+}
+
+// Handler is the echo HTTPErrorHandler which writes every error as a problem in the language of the request.
+// The request id is the one which is set on the response by the request id middleware.
+func Handler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	p := *from(err)
+	p.Instance = c.Request().URL.Path
+	p.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+	p.Localize(i18n.FromContext(c.Request().Context()))
+
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("request %s failed: %s", p.RequestID, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(p.Status)
+	} else {
+		err = Write(c.Response(), &p)
+	}
+
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,245 @@
+// Package problem reports the HTTP errors as the RFC 7807 problem details (application/problem+json).
+// Each problem has a stable code which clients can depend on, the request id of the failed request
+// and the per-field messages of the validation errors.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// ErrInvalid is the message of the invalid parameters which don't have a validation error.
+var ErrInvalid = errors.New("is invalid")
+
+// Code is a stable identifier of a problem, the titles and details may change but codes don't.
+type Code string
+
+const (
+	CodeBadRequest           Code = "bad_request"
+	CodeMalformedBody        Code = "malformed_body"
+	CodeValidationFailed     Code = "validation_failed"
+	CodeInvalidParameter     Code = "invalid_parameter"
+	CodeUnauthorized         Code = "unauthorized"
+	CodeInvalidToken         Code = "invalid_token"
+	CodeInvalidCredentials   Code = "invalid_credentials"
+	CodeForbidden            Code = "forbidden"
+	CodeNotFound             Code = "not_found"
+	CodeStudentNotFound      Code = "student_not_found"
+	CodeCourseNotFound       Code = "course_not_found"
+	CodeWebhookNotFound      Code = "webhook_not_found"
+	CodeDeliveryNotFound     Code = "delivery_not_found"
+	CodeAPIKeyNotFound       Code = "api_key_not_found"
+	CodeEnrollmentNotFound   Code = "enrollment_not_found"
+	CodeUnknownTenant        Code = "unknown_tenant"
+	CodeTenantRequired       Code = "tenant_required"
+	CodeTenantMismatch       Code = "tenant_mismatch"
+	CodeMethodNotAllowed     Code = "method_not_allowed"
+	CodeNotAcceptable        Code = "not_acceptable"
+	CodeConflict             Code = "conflict"
+	CodeStudentExists        Code = "student_exists"
+	CodeCourseExists         Code = "course_exists"
+	CodeNationalIDTaken      Code = "national_id_taken"
+	CodeCourseFull           Code = "course_full"
+	CodeRequestTooLarge      Code = "request_too_large"
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+	CodeTooManyRequests      Code = "too_many_requests"
+	CodeInternal             Code = "internal_error"
+	CodeUnavailable          Code = "service_unavailable"
+)
+
+// codes are the generic codes of the statuses, they are used for the errors which don't have a problem.
+// nolint: gochecknoglobals
+var codes = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusNotAcceptable:         CodeNotAcceptable,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodeRequestTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// CodeOf returns the generic code of the status.
+func CodeOf(status int) Code {
+	if c, ok := codes[status]; ok {
+		return c
+	}
+
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+
+	return CodeBadRequest
+}
+
+// Problem is an RFC 7807 problem, it is an error so handlers can return it.
+type Problem struct {
+	// Type identifies the problem type, it is derived from the code.
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Instance is the path of the failed request.
+	Instance  string `json:"instance,omitempty"`
+	Code      Code   `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors maps the invalid fields (e.g. "name" or "0.start" of an array body) to their messages.
+	Errors map[string]string `json:"errors,omitempty"`
+
+	// Err is the cause of the problem, it is logged and never sent to the client.
+	Err error `json:"-"`
+
+	// fields and detail are the errors behind the messages, so they can be localized.
+	fields map[string]error
+	detail error
+}
+
+// New returns a problem with the given status, code and detail.
+func New(status int, code Code, detail string) *Problem {
+	return &Problem{
+		Type:      "urn:students:problem:" + string(code),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  "",
+		Code:      code,
+		RequestID: "",
+		Errors:    nil,
+		Err:       nil,
+		fields:    nil,
+		detail:    nil,
+	}
+}
+
+func (p *Problem) Error() string {
+	if p.Err != nil {
+		return fmt.Sprintf("%s (%d): %s: %s", p.Code, p.Status, p.Detail, p.Err)
+	}
+
+	return fmt.Sprintf("%s (%d): %s", p.Code, p.Status, p.Detail)
+}
+
+func (p *Problem) Unwrap() error {
+	return p.Err
+}
+
+// Internal hides the cause of an internal error from the client.
+func Internal(err error) *Problem {
+	p := New(http.StatusInternalServerError, CodeInternal, "")
+	p.Err = err
+
+	return p
+}
+
+// Validation reports the ozzo-validation errors of a request body per field,
+// the errors which don't belong to a field are reported in the detail.
+func Validation(err error) *Problem {
+	p := New(http.StatusBadRequest, CodeValidationFailed, "request has invalid fields")
+	p.Err = err
+
+	fields := make(map[string]error)
+	if !flatten("", err, fields) {
+		p.Detail = err.Error()
+		p.detail = err
+	}
+
+	if len(fields) != 0 {
+		p.fields = fields
+		p.Errors = messages(fields)
+	}
+
+	return p
+}
+
+// Param reports an invalid path or query parameter.
+func Param(name string, err error) *Problem {
+	p := New(http.StatusBadRequest, CodeInvalidParameter, "request has invalid parameters")
+	p.Err = err
+
+	var cause error = ErrInvalid
+
+	var ve validation.Error
+	if errors.As(err, &ve) {
+		cause = ve
+	}
+
+	p.fields = map[string]error{name: cause}
+	p.Errors = messages(p.fields)
+
+	return p
+}
+
+// Localize translates the title, detail and field messages of the problem.
+func (p *Problem) Localize(lang i18n.Lang) {
+	p.Title = i18n.T(lang, p.Title)
+
+	if p.detail != nil {
+		p.Detail = i18n.Error(lang, p.detail)
+	} else {
+		p.Detail = i18n.T(lang, p.Detail)
+	}
+
+	if p.fields != nil {
+		p.Errors = make(map[string]string, len(p.fields))
+
+		for name, err := range p.fields {
+			p.Errors[name] = i18n.Error(lang, err)
+		}
+	}
+}
+
+func messages(fields map[string]error) map[string]string {
+	msgs := make(map[string]string, len(fields))
+
+	for name, err := range fields {
+		msgs[name] = err.Error()
+	}
+
+	return msgs
+}
+
+// flatten adds the messages of the nested validation errors into the fields with their dotted paths.
+func flatten(prefix string, err error, fields map[string]error) bool {
+	var es validation.Errors
+	if !errors.As(err, &es) {
+		return false
+	}
+
+	for name, e := range es {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		if !flatten(name, e, fields) {
+			fields[name] = e
+		}
+	}
+
+	return true
+}
+
+// Write writes the problem as the response.
+func Write(w http.ResponseWriter, p *Problem) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+
+	err := json.NewEncoder(w).Encode(p)
+	if err != nil {
+		return fmt.Errorf("cannot write the problem %w", err)
+	}
+
+	return nil
+}
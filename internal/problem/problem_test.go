@@ -0,0 +1,168 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+func TestValidation(t *testing.T) {
+	t.Parallel()
+
+	req := request.Meetings{
+		{Weekday: "saturday", Start: "10:00", End: "09:00", Location: ""},
+		{Weekday: "caturday", Start: "25:00", End: "12:00", Location: ""},
+	}
+
+	p := problem.Validation(req.Validate())
+
+	if p.Status != http.StatusBadRequest || p.Code != problem.CodeValidationFailed {
+		t.Fatalf("expected validation problem, got %v", p)
+	}
+
+	for _, field := range []string{"0.end", "1.weekday", "1.start"} {
+		if p.Errors[field] == "" {
+			t.Errorf("expected an error for %s, got %v", field, p.Errors)
+		}
+	}
+
+	if len(p.Errors) != 3 {
+		t.Errorf("expected 3 invalid fields, got %v", p.Errors)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(middleware.RequestID())
+	app.Use(i18n.Middleware())
+
+	app.POST("/students", func(c echo.Context) error {
+		var req request.StudentCreate
+
+		err := c.Bind(&req)
+		if err != nil {
+			return problem.Bind(err)
+		}
+
+		return problem.Validation(req.Validate())
+	})
+	app.GET("/fail", func(echo.Context) error {
+		return errors.New("database is on fire") // nolint: err113
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   problem.Code
+		field  string
+	}{
+		{"validation", http.MethodPost, "/students", `{"name": "Bob2", "phone": "123"}`, 400, problem.CodeValidationFailed, "phone"},
+		{"malformed", http.MethodPost, "/students", `{"name": `, 400, problem.CodeMalformedBody, ""},
+		{"route", http.MethodGet, "/courses", "", 404, problem.CodeNotFound, ""},
+		{"method", http.MethodDelete, "/students", "", 405, problem.CodeMethodNotAllowed, ""},
+		{"internal", http.MethodGet, "/fail", "", 500, problem.CodeInternal, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, w.Code)
+			}
+
+			if ct := w.Header().Get(echo.HeaderContentType); ct != problem.ContentType {
+				t.Errorf("expected problem content type, got %s", ct)
+			}
+
+			var p problem.Problem
+
+			err := json.NewDecoder(w.Body).Decode(&p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if p.Code != tc.code || p.Status != tc.status || p.Instance != tc.path {
+				t.Errorf("unexpected problem %+v", p)
+			}
+
+			if p.RequestID == "" || p.RequestID != w.Header().Get(echo.HeaderXRequestID) {
+				t.Errorf("expected the request id %s, got %s", w.Header().Get(echo.HeaderXRequestID), p.RequestID)
+			}
+
+			if tc.field != "" && p.Errors[tc.field] == "" {
+				t.Errorf("expected an error for %s, got %v", tc.field, p.Errors)
+			}
+
+			if tc.status == http.StatusInternalServerError && strings.Contains(p.Detail, "fire") {
+				t.Errorf("internal error is leaked: %s", p.Detail)
+			}
+		})
+	}
+}
+
+func TestHandler_Localized(t *testing.T) {
+	t.Parallel()
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(i18n.Middleware())
+
+	app.POST("/students", func(c echo.Context) error {
+		var req request.StudentCreate
+
+		err := c.Bind(&req)
+		if err != nil {
+			return problem.Bind(err)
+		}
+
+		return problem.Validation(req.Validate())
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/students", strings.NewReader(`{"name": "", "national_id": "1234567890"}`))
+	r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	r.Header.Set("Accept-Language", "fa-IR,fa;q=0.9,en;q=0.8")
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+
+	var p problem.Problem
+
+	err := json.NewDecoder(w.Body).Decode(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Code != problem.CodeValidationFailed || p.Title != "درخواست نامعتبر" {
+		t.Errorf("unexpected problem %+v", p)
+	}
+
+	if p.Errors["name"] != "نباید خالی باشد" || p.Errors["national_id"] != "باید یک کد ملی معتبر باشد" {
+		t.Errorf("expected persian field messages, got %v", p.Errors)
+	}
+
+	if lang := w.Header().Get("Content-Language"); lang != "fa" {
+		t.Errorf("expected fa content language, got %s", lang)
+	}
+}
@@ -0,0 +1,153 @@
+// Package pubsub is an in-process broker of the domain events, it receives the events
+// as an outbox sink and fans them out to the subscribers (e.g. GraphQL subscriptions).
+//
+// Publishing never blocks: each subscriber has a bounded buffer and a subscriber which
+// doesn't keep up is dropped by closing its channel, so a slow client can't hold the
+// dispatcher or the other subscribers back.
+package pubsub
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/1995parham-teaching/students/internal/event"
+)
+
+const (
+	// DefaultBuffer is the buffer size of each subscriber.
+	DefaultBuffer = 64
+	// DefaultHistory is the number of recent events which are kept to skip the duplicates
+	// of the at least once delivery and to resume the subscriptions.
+	DefaultHistory = 1024
+)
+
+type Broker struct {
+	lock        *sync.Mutex
+	subscribers map[chan event.Event]struct{}
+	// history is a ring of the recent events in their arrival order with index as its set of ids.
+	history []event.Event
+	index   map[uint64]struct{}
+	next    int
+}
+
+func New(history int) *Broker {
+	return &Broker{
+		lock:        new(sync.Mutex),
+		subscribers: make(map[chan event.Event]struct{}),
+		history:     make([]event.Event, 0, history),
+		index:       make(map[uint64]struct{}, history),
+		next:        0,
+	}
+}
+
+// Deliver publishes the event to all the subscribers, it implements event.Sink.
+func (b *Broker) Deliver(_ context.Context, e event.Event) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.remember(e) {
+		return nil
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("slow event subscriber is dropped after event %d", e.ID)
+
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return nil
+}
+
+// remember records the event and reports whether it is a new one.
+func (b *Broker) remember(e event.Event) bool {
+	if cap(b.history) == 0 {
+		return true
+	}
+
+	if _, ok := b.index[e.ID]; ok {
+		return false
+	}
+
+	if len(b.history) < cap(b.history) {
+		b.history = append(b.history, e)
+	} else {
+		delete(b.index, b.history[b.next].ID)
+		b.history[b.next] = e
+		b.next = (b.next + 1) % len(b.history)
+	}
+
+	b.index[e.ID] = struct{}{}
+
+	return true
+}
+
+// recent returns the remembered events in their arrival order.
+func (b *Broker) recent() []event.Event {
+	return append(slices.Clone(b.history[b.next:]), b.history[:b.next]...)
+}
+
+// Subscribe returns a channel of the events which are published after the subscription.
+// The channel is closed when the context is canceled or the subscriber is dropped
+// because its buffer is full.
+func (b *Broker) Subscribe(ctx context.Context, buffer int) <-chan event.Event {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.subscribe(ctx, buffer, nil)
+}
+
+// Resume is Subscribe which first sends the remembered events after the given event. When the
+// event is not remembered anymore, the remembered events with a greater id are sent instead.
+func (b *Broker) Resume(ctx context.Context, buffer int, lastID uint64) <-chan event.Event {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	events := b.recent()
+
+	var replay []event.Event
+
+	if _, ok := b.index[lastID]; ok {
+		i := slices.IndexFunc(events, func(e event.Event) bool { return e.ID == lastID })
+		replay = events[i+1:]
+	} else {
+		for _, e := range events {
+			if e.ID > lastID {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	return b.subscribe(ctx, buffer, replay)
+}
+
+// subscribe registers a subscriber with the replay events in its buffer, the lock must be held.
+func (b *Broker) subscribe(ctx context.Context, buffer int, replay []event.Event) <-chan event.Event {
+	ch := make(chan event.Event, buffer+len(replay))
+
+	for _, e := range replay {
+		ch <- e
+	}
+
+	b.subscribers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,120 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/pubsub"
+)
+
+func publish(t *testing.T, b *pubsub.Broker, id uint64) {
+	t.Helper()
+
+	err := b.Deliver(context.Background(), event.Event{ID: id, Type: event.StudentCreated, Payload: nil})
+	if err != nil {
+		t.Fatalf("failed to publish event: %v", err)
+	}
+}
+
+func TestBroker_Duplicates(t *testing.T) {
+	t.Parallel()
+
+	b := pubsub.New(2)
+	ch := b.Subscribe(t.Context(), 10)
+
+	for _, id := range []uint64{1, 2, 1, 3, 1} {
+		publish(t, b, id)
+	}
+
+	// 1 is forgotten after 3 is published, because the history has room for two events.
+	want := []uint64{1, 2, 3, 1}
+
+	for _, id := range want {
+		e := <-ch
+		if e.ID != id {
+			t.Errorf("expected event %d, got %d", id, e.ID)
+		}
+	}
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	b := pubsub.New(pubsub.DefaultHistory)
+
+	slow := b.Subscribe(t.Context(), 1)
+	fast := b.Subscribe(t.Context(), 10)
+
+	for id := range uint64(3) {
+		publish(t, b, id)
+	}
+
+	if e, ok := <-slow; !ok || e.ID != 0 {
+		t.Fatalf("expected buffered event before the drop, got %v %v", e, ok)
+	}
+
+	if _, ok := <-slow; ok {
+		t.Fatal("expected slow subscriber to be dropped")
+	}
+
+	for id := range uint64(3) {
+		e := <-fast
+		if e.ID != id {
+			t.Errorf("expected event %d, got %d", id, e.ID)
+		}
+	}
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	b := pubsub.New(pubsub.DefaultHistory)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := b.Subscribe(ctx, 1)
+
+	cancel()
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed on cancel")
+	}
+
+	publish(t, b, 1)
+}
+
+func TestBroker_Resume(t *testing.T) {
+	t.Parallel()
+
+	b := pubsub.New(3)
+
+	// 2 is retried by the dispatcher, so it arrives after 3.
+	for _, id := range []uint64{1, 3, 2, 4} {
+		publish(t, b, id)
+	}
+
+	cases := []struct {
+		name   string
+		lastID uint64
+		want   []uint64
+	}{
+		{"remembered", 3, []uint64{2, 4}},
+		{"forgotten", 1, []uint64{3, 2, 4}},
+		{"latest", 4, nil},
+	}
+
+	for _, c := range cases {
+		ch := b.Resume(t.Context(), 0, c.lastID)
+
+		for _, id := range c.want {
+			e := <-ch
+			if e.ID != id {
+				t.Errorf("%s: expected event %d, got %d", c.name, id, e.ID)
+			}
+		}
+
+		if len(ch) != 0 {
+			t.Errorf("%s: expected %d events, got %d more", c.name, len(c.want), len(ch))
+		}
+	}
+}
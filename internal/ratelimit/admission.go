@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+var ErrBusy = errors.New("server is busy, try again later")
+
+// Admission runs a limited number of requests at once and queues the others for a limited time,
+// the requests which don't fit in the queue or wait too long are rejected, so the database
+// is not locked by the writers which pile up.
+type Admission struct {
+	slots  chan struct{}
+	queued atomic.Int64
+	queue  int64
+	wait   time.Duration
+}
+
+// NewAdmission returns an admission which runs concurrency requests at once and queues at most
+// queue requests for at most the wait duration.
+func NewAdmission(concurrency int, queue int, wait time.Duration) *Admission {
+	return &Admission{
+		slots:  make(chan struct{}, max(concurrency, 1)),
+		queued: atomic.Int64{},
+		queue:  int64(queue),
+		wait:   wait,
+	}
+}
+
+// Queued returns the number of the requests which wait for their turn.
+func (a *Admission) Queued() int {
+	return int(a.queued.Load())
+}
+
+// acquire takes a slot and returns its release, it returns false when the request is rejected.
+func (a *Admission) acquire(c echo.Context) (func(), bool) {
+	release := func() { <-a.slots }
+
+	select {
+	case a.slots <- struct{}{}:
+		return release, true
+	default:
+	}
+
+	if a.queued.Add(1) > a.queue {
+		a.queued.Add(-1)
+
+		return nil, false
+	}
+	defer a.queued.Add(-1)
+
+	timer := time.NewTimer(a.wait)
+	defer timer.Stop()
+
+	select {
+	case a.slots <- struct{}{}:
+		return release, true
+	case <-timer.C:
+		return nil, false
+	case <-c.Request().Context().Done():
+		return nil, false
+	}
+}
+
+// Middleware admits the requests which are not skipped through the queue, the rejected requests
+// are asked to retry after the queue wait.
+func (a *Admission) Middleware(skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+
+			done, ok := a.acquire(c)
+			if !ok {
+				return TooManyRequests(c, max(a.wait, time.Second), ErrBusy)
+			}
+			defer done()
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package ratelimit protects the server from the bursts of requests. Each client, an api key or an ip address,
+// has a token bucket in each route group, so a client which exhausts one group can still use the others,
+// and the expensive routes are admitted through a bounded queue instead of piling up on the database.
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// GroupDefault is the group of the routes which are not in another group.
+	GroupDefault = "default"
+	GroupAuth    = "auth"
+	// GroupRegistration is the group of the routes which register the students into the courses.
+	GroupRegistration = "registration"
+	GroupGraphQL      = "graphql"
+	// GroupIP is the group of all the requests of an ip address before their authentication, so the invalid
+	// credentials are limited before they are checked and the api keys cannot be guessed at the speed of the server.
+	GroupIP = "ip"
+)
+
+// IdleTimeout is how long the bucket of a client is kept after its last request.
+const IdleTimeout = 10 * time.Minute
+
+var (
+	ErrLimited      = errors.New("rate limit is exceeded")
+	ErrInvalidLimit = errors.New("rate limit must be group=rate/burst")
+)
+
+// Budget is the sustained rate of a group in requests per second and the burst which is allowed above it,
+// a zero rate doesn't limit the group.
+type Budget struct {
+	Rate  float64
+	Burst int
+}
+
+func (b Budget) String() string {
+	return strconv.FormatFloat(b.Rate, 'f', -1, 64) + "/" + strconv.Itoa(b.Burst)
+}
+
+// DefaultBudgets returns the budgets of the groups, the registration has a small burst because the students
+// register into a handful of courses and the ip budget is above the others because it is shared by all the groups.
+func DefaultBudgets() map[string]Budget {
+	return map[string]Budget{
+		GroupDefault:      {Rate: 20, Burst: 40},
+		GroupAuth:         {Rate: 1, Burst: 10},
+		GroupRegistration: {Rate: 2, Burst: 5},
+		GroupGraphQL:      {Rate: 10, Burst: 20},
+		GroupIP:           {Rate: 50, Burst: 100},
+	}
+}
+
+// ParseBudget parses a group=rate/burst budget, e.g. registration=2/5.
+func ParseBudget(spec string) (string, Budget, error) {
+	group, value, ok := strings.Cut(spec, "=")
+	if !ok || group == "" {
+		return "", Budget{}, ErrInvalidLimit
+	}
+
+	r, b, ok := strings.Cut(value, "/")
+	if !ok {
+		return "", Budget{}, ErrInvalidLimit
+	}
+
+	limit, err := strconv.ParseFloat(r, 64)
+	if err != nil || limit < 0 || math.IsInf(limit, 0) || math.IsNaN(limit) {
+		return "", Budget{}, fmt.Errorf("%w: invalid rate %q", ErrInvalidLimit, r)
+	}
+
+	burst, err := strconv.Atoi(b)
+	if err != nil || burst < 1 {
+		return "", Budget{}, fmt.Errorf("%w: invalid burst %q", ErrInvalidLimit, b)
+	}
+
+	return group, Budget{Rate: limit, Burst: burst}, nil
+}
+
+type key struct {
+	group  string
+	client string
+}
+
+type bucket struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+// Limiter keeps a token bucket for each client in each group.
+type Limiter struct {
+	budgets map[string]Budget
+
+	lock    sync.Mutex
+	buckets map[key]*bucket
+	pruned  time.Time
+}
+
+// New returns a limiter with the given budgets, the groups without a budget use the default group budget.
+func New(budgets map[string]Budget) *Limiter {
+	return &Limiter{
+		budgets: budgets,
+		lock:    sync.Mutex{},
+		buckets: make(map[key]*bucket),
+		pruned:  time.Now(),
+	}
+}
+
+func (l *Limiter) budget(group string) Budget {
+	b, ok := l.budgets[group]
+	if !ok {
+		return l.budgets[GroupDefault]
+	}
+
+	return b
+}
+
+// Allow takes a token from the bucket of the client in the group, when the bucket is empty it returns
+// false with how long the client must wait for the next token.
+func (l *Limiter) Allow(group string, client string, now time.Time) (bool, time.Duration) {
+	b := l.budget(group)
+	if b.Rate == 0 {
+		return true, 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.prune(now)
+
+	k := key{group: group, client: client}
+
+	bk, ok := l.buckets[k]
+	if !ok {
+		bk = &bucket{limiter: rate.NewLimiter(rate.Limit(b.Rate), b.Burst), seen: now}
+		l.buckets[k] = bk
+	}
+
+	bk.seen = now
+
+	r := bk.limiter.ReserveN(now, 1)
+
+	delay := r.DelayFrom(now)
+	if delay > 0 {
+		// the token is not taken, so the rejected requests don't delay the next ones.
+		r.CancelAt(now)
+
+		return false, delay
+	}
+
+	return true, 0
+}
+
+// prune forgets the buckets of the idle clients, their buckets are full again by now.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < IdleTimeout {
+		return
+	}
+
+	for k, b := range l.buckets {
+		if now.Sub(b.seen) > IdleTimeout {
+			delete(l.buckets, k)
+		}
+	}
+
+	l.pruned = now
+}
+
+// TooManyRequests returns the 429 problem which asks the client to retry after the given duration.
+func TooManyRequests(c echo.Context, after time.Duration, err error) error {
+	c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+
+	p := problem.New(http.StatusTooManyRequests, problem.CodeTooManyRequests, err.Error())
+	p.Err = err
+
+	return p
+}
+
+// Middleware limits the requests of each client in the group of their route.
+func (l *Limiter) Middleware(group func(echo.Context) string, client func(echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ok, after := l.Allow(group(c), client(c), time.Now())
+			if !ok {
+				return TooManyRequests(c, after, ErrLimited)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/problem"
+	"github.com/1995parham-teaching/students/internal/ratelimit"
+	"github.com/labstack/echo/v4"
+)
+
+func TestParseBudget(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		spec   string
+		group  string
+		budget ratelimit.Budget
+		valid  bool
+	}{
+		{"registration=2/5", "registration", ratelimit.Budget{Rate: 2, Burst: 5}, true},
+		{"auth=0.5/3", "auth", ratelimit.Budget{Rate: 0.5, Burst: 3}, true},
+		{"graphql=0/1", "graphql", ratelimit.Budget{Rate: 0, Burst: 1}, true},
+		{"registration", "", ratelimit.Budget{}, false},
+		{"=2/5", "", ratelimit.Budget{}, false},
+		{"auth=2", "", ratelimit.Budget{}, false},
+		{"auth=-1/5", "", ratelimit.Budget{}, false},
+		{"auth=many/5", "", ratelimit.Budget{}, false},
+		{"auth=2/0", "", ratelimit.Budget{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.spec, func(t *testing.T) {
+			t.Parallel()
+
+			group, b, err := ratelimit.ParseBudget(tc.spec)
+			if !tc.valid {
+				if !errors.Is(err, ratelimit.ErrInvalidLimit) {
+					t.Fatalf("expected an invalid limit, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if group != tc.group || b != tc.budget {
+				t.Errorf("expected %s=%s, got %s=%s", tc.group, tc.budget, group, b)
+			}
+		})
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	l := ratelimit.New(map[string]ratelimit.Budget{
+		ratelimit.GroupDefault:      {Rate: 0, Burst: 1},
+		ratelimit.GroupRegistration: {Rate: 1, Burst: 2},
+	})
+
+	now := time.Now()
+
+	for i := range 2 {
+		if ok, _ := l.Allow(ratelimit.GroupRegistration, "ip:1.1.1.1", now); !ok {
+			t.Fatalf("request %d is in the burst and must be allowed", i)
+		}
+	}
+
+	ok, after := l.Allow(ratelimit.GroupRegistration, "ip:1.1.1.1", now)
+	if ok || after <= 0 || after > time.Second {
+		t.Fatalf("expected a rejection with a wait up to a second, got %t and %s", ok, after)
+	}
+
+	// the rejected request didn't take a token.
+	if ok, _ := l.Allow(ratelimit.GroupRegistration, "ip:1.1.1.1", now.Add(time.Second)); !ok {
+		t.Error("expected a token after a second")
+	}
+
+	if ok, _ := l.Allow(ratelimit.GroupRegistration, "ip:2.2.2.2", now); !ok {
+		t.Error("expected another client to have its own bucket")
+	}
+
+	// the default group is not limited and the groups without a budget use it.
+	for range 10 {
+		if ok, _ := l.Allow(ratelimit.GroupGraphQL, "ip:1.1.1.1", now); !ok {
+			t.Fatal("expected the unlimited group to allow all the requests")
+		}
+	}
+}
+
+func TestAdmission(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	admission := ratelimit.NewAdmission(1, 1, time.Minute)
+
+	app := echo.New()
+	app.HTTPErrorHandler = problem.Handler
+	app.Use(admission.Middleware(func(c echo.Context) bool {
+		return c.Path() == "/public"
+	}))
+	app.GET("/register", func(c echo.Context) error {
+		started <- struct{}{}
+		<-release
+
+		return c.NoContent(http.StatusNoContent)
+	})
+	app.GET("/public", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+
+		return w
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		if w := serve(httptest.NewRequest(http.MethodGet, "/register", nil)); w.Code != http.StatusNoContent {
+			t.Errorf("expected the admitted request to succeed, got %d", w.Code)
+		}
+	})
+
+	<-started
+
+	// the second request waits in the queue until it is canceled and the third one doesn't fit in it.
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan *httptest.ResponseRecorder)
+
+	go func() { queued <- serve(httptest.NewRequestWithContext(ctx, http.MethodGet, "/register", nil)) }()
+
+	for admission.Queued() == 0 {
+		runtime.Gosched()
+	}
+
+	full := serve(httptest.NewRequest(http.MethodGet, "/register", nil))
+
+	cancel()
+
+	for _, w := range []*httptest.ResponseRecorder{full, <-queued} {
+		if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "60" {
+			t.Errorf("expected 429 with Retry-After, got %d with %q", w.Code, w.Header().Get("Retry-After"))
+		}
+	}
+
+	if w := serve(httptest.NewRequest(http.MethodGet, "/public", nil)); w.Code != http.StatusNoContent {
+		t.Errorf("expected the skipped route to be admitted, got %d", w.Code)
+	}
+
+	close(release)
+	wg.Wait()
+
+	// the slot is released.
+	go func() { <-started }()
+
+	if w := serve(httptest.NewRequest(http.MethodGet, "/register", nil)); w.Code != http.StatusNoContent {
+		t.Errorf("expected the request to be admitted after the release, got %d", w.Code)
+	}
+}
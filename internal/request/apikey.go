@@ -0,0 +1,65 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/policy"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+var ErrPastExpiry = errors.New("must be in the future")
+
+// expiry validates the optional expiry of the api keys.
+func expiry(t *time.Time) validation.Rule {
+	return validation.By(func(any) error {
+		if t != nil && !t.After(time.Now()) {
+			return ErrPastExpiry
+		}
+
+		return nil
+	})
+}
+
+// APIKeyCreate creates an api key with the given scopes, a missing expires_at creates a key which never expires.
+type APIKeyCreate struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r APIKeyCreate) Validate() error {
+	scopes := make([]any, 0, len(policy.Actions()))
+	for _, a := range policy.Actions() {
+		scopes = append(scopes, string(a))
+	}
+
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(0, MaxUsernameLen), is.PrintableASCII),
+		validation.Field(&r.Scopes, validation.Required, validation.Each(validation.In(scopes...))),
+		validation.Field(&r.ExpiresAt, expiry(r.ExpiresAt)),
+	)
+	if err != nil {
+		return fmt.Errorf("api key creation request validation failed %w", err)
+	}
+
+	return nil
+}
+
+// APIKeyRotate replaces the secret of an api key, a missing expires_at keeps its expiry.
+type APIKeyRotate struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r APIKeyRotate) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.ExpiresAt, expiry(r.ExpiresAt)),
+	)
+	if err != nil {
+		return fmt.Errorf("api key rotation request validation failed %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+const (
+	// MinPasswordLen is the minimum length of the passwords.
+	MinPasswordLen = 8
+	// MaxUsernameLen is the maximum length of the usernames.
+	MaxUsernameLen = 64
+)
+
+// Login logs the user in with its password.
+type Login struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (r Login) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.Username, validation.Required),
+		validation.Field(&r.Password, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("login request validation failed %w", err)
+	}
+
+	return nil
+}
+
+// Token carries a refresh token to be refreshed or any token to be revoked.
+type Token struct {
+	Token string `json:"token"`
+}
+
+func (r Token) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.Token, validation.Required),
+	)
+	if err != nil {
+		return fmt.Errorf("token request validation failed %w", err)
+	}
+
+	return nil
+}
+
+// UserCreate creates a user, the users with the student role must be linked to their student.
+type UserCreate struct {
+	Username  string
+	Password  string
+	Role      string
+	StudentID string
+}
+
+func (r UserCreate) Validate() error {
+	roles := make([]any, 0, len(model.Roles()))
+	for _, role := range model.Roles() {
+		roles = append(roles, string(role))
+	}
+
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.Username, validation.Required, validation.Length(3, MaxUsernameLen), is.PrintableASCII),
+		validation.Field(&r.Password, validation.Required, validation.Length(MinPasswordLen, 0)),
+		validation.Field(&r.Role, validation.Required, validation.In(roles...)),
+		validation.Field(&r.StudentID, validation.When(r.Role == string(model.RoleStudent), validation.Required)),
+	)
+	if err != nil {
+		return fmt.Errorf("user creation request validation failed %w", err)
+	}
+
+	return nil
+}
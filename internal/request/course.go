@@ -2,26 +2,27 @@ package request
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/names"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
+// CourseCreate has an optional capacity, zero means the course has no limit.
+// The name is normalized and it can have digits and symbols, e.g. "C++ Programming".
+// The optional instructor is the username of the instructor who can see the course roster.
 type CourseCreate struct {
-	Name string `json:"name"`
+	Name       string `json:"name"`
+	Capacity   int    `json:"capacity"`
+	Instructor string `json:"instructor"`
 }
 
 func (r CourseCreate) Validate() error {
 	err := validation.ValidateStruct(&r,
-		validation.Field(&r.Name, validation.Length(1, 0), validation.Required),
-	)
-	if err != nil {
-		return fmt.Errorf("course creation request validation failed %w", err)
-	}
-
-	err = validation.Validate(strings.Fields(r.Name),
-		validation.Each(is.UTFLetter),
+		validation.Field(&r.Name, validation.Required, names.Course),
+		validation.Field(&r.Capacity, validation.Min(0)),
+		validation.Field(&r.Instructor, validation.Length(0, MaxUsernameLen), is.PrintableASCII),
 	)
 	if err != nil {
 		return fmt.Errorf("course creation request validation failed %w", err)
@@ -29,3 +30,13 @@ func (r CourseCreate) Validate() error {
 
 	return nil
 }
+
+// Course returns the course of a valid request with the normalized name.
+func (r CourseCreate) Course(id string) model.Course {
+	return model.Course{
+		Name:       names.Normalize(r.Name),
+		ID:         id,
+		Capacity:   r.Capacity,
+		Instructor: r.Instructor,
+	}
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// CourseIDLen is the length of the course ids.
+const CourseIDLen = 8
+
+// EnrollmentCreate enrolls the student of the path into the course.
+type EnrollmentCreate struct {
+	CourseID string `json:"course_id"`
+}
+
+func (r EnrollmentCreate) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.CourseID, validation.Required, validation.Length(CourseIDLen, CourseIDLen), is.Digit),
+	)
+	if err != nil {
+		return fmt.Errorf("enrollment creation request validation failed %w", err)
+	}
+
+	return nil
+}
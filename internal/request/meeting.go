@@ -0,0 +1,77 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/1995parham-teaching/students/internal/ical"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// MaxMeetings is the maximum number of weekly meetings of a course.
+const MaxMeetings = 14
+
+var (
+	ErrMeetingEnd       = errors.New("meeting must end after its start")
+	ErrMeetingDuplicate = errors.New("meeting starts with another meeting")
+)
+
+// clock matches the HH:MM times.
+var clock = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`) // nolint: gochecknoglobals
+
+type Meeting struct {
+	Weekday  string `json:"weekday"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location"`
+}
+
+func (r Meeting) Validate() error {
+	weekdays := make([]any, 0, len(ical.Weekdays))
+	for _, w := range ical.Weekdays {
+		weekdays = append(weekdays, w.Name)
+	}
+
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.Weekday, validation.Required, validation.In(weekdays...)),
+		validation.Field(&r.Start, validation.Required, validation.Match(clock)),
+		validation.Field(&r.End, validation.Required, validation.Match(clock)),
+		validation.Field(&r.Location, validation.Length(0, 100)),
+	)
+	if err != nil {
+		return fmt.Errorf("meeting request validation failed %w", err)
+	}
+
+	// HH:MM times are ordered as strings.
+	if r.End <= r.Start {
+		return fmt.Errorf("meeting request validation failed %w", validation.Errors{"end": ErrMeetingEnd})
+	}
+
+	return nil
+}
+
+// Meetings replaces the weekly meetings of a course.
+type Meetings []Meeting
+
+func (r Meetings) Validate() error {
+	err := validation.Validate([]Meeting(r), validation.Length(0, MaxMeetings))
+	if err != nil {
+		return fmt.Errorf("meetings request validation failed %w", err)
+	}
+
+	// the weekday and the start identify the meetings of a course, e.g. in the calendar events.
+	starts := make(map[[2]string]struct{}, len(r))
+
+	for i, m := range r {
+		if _, ok := starts[[2]string{m.Weekday, m.Start}]; ok {
+			return fmt.Errorf("meetings request validation failed %w",
+				validation.Errors{strconv.Itoa(i): ErrMeetingDuplicate})
+		}
+
+		starts[[2]string{m.Weekday, m.Start}] = struct{}{}
+	}
+
+	return nil
+}
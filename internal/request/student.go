@@ -1,31 +1,166 @@
 package request
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/1995parham-teaching/students/internal/jalali"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/names"
+	"github.com/1995parham-teaching/students/internal/nationalid"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
+const (
+	// MinEntranceYear and MaxEntranceYear are the range of the accepted entrance years in the jalali calendar.
+	MinEntranceYear = 1300
+	MaxEntranceYear = 1500
+
+	MaxEmailLen = 254
+	MaxMajorLen = 64
+)
+
+var (
+	ErrNationalID   = errors.New("must be a valid national code")
+	ErrFutureBirth  = errors.New("must not be in the future")
+	ErrAncientBirth = errors.New("must be after 1900")
+)
+
+// mobile matches the iranian mobile numbers with or without the country code.
+var mobile = regexp.MustCompile(`^(\+98|0098|0)?9\d{9}$`) // nolint: gochecknoglobals
+
+// StudentCreate creates a student, the entrance is optional and it defaults to the running semester.
+// The profile fields are optional and the status defaults to active.
 type StudentCreate struct {
-	Name string `json:"name"`
+	Name             string `json:"name"`
+	EntranceYear     int    `json:"entrance_year"`
+	EntranceSemester string `json:"entrance_semester"`
+	Email            string `json:"email"`
+	Phone            string `json:"phone"`
+	NationalID       string `json:"national_id"`
+	// BirthDate is in the yyyy-mm-dd format of the Calendar.
+	BirthDate string `json:"birth_date"`
+	Major     string `json:"major"`
+	Status    string `json:"status"`
+
+	// Calendar is the calendar of the birth date, it is not a part of the request body.
+	Calendar jalali.Calendar `json:"-"`
 }
 
 func (r StudentCreate) Validate() error {
+	statuses := make([]any, 0, len(model.StudentStatuses()))
+	for _, s := range model.StudentStatuses() {
+		statuses = append(statuses, string(s))
+	}
+
 	err := validation.ValidateStruct(&r,
-		validation.Field(&r.Name, validation.Length(1, 0), validation.Required),
+		validation.Field(&r.Name, validation.Required, names.Person),
+		validation.Field(&r.EntranceYear,
+			validation.When(r.EntranceYear != 0, validation.Min(MinEntranceYear), validation.Max(MaxEntranceYear))),
+		validation.Field(&r.EntranceSemester,
+			validation.In(string(model.SemesterFall), string(model.SemesterSpring)),
+			validation.When(r.EntranceYear == 0, validation.Empty)),
+		validation.Field(&r.Email, validation.Length(0, MaxEmailLen), is.EmailFormat),
+		validation.Field(&r.Phone, validation.Match(mobile)),
+		validation.Field(&r.NationalID, validation.By(func(any) error {
+			if r.NationalID != "" && !nationalid.Valid(r.NationalID) {
+				return ErrNationalID
+			}
+
+			return nil
+		})),
+		validation.Field(&r.BirthDate, validation.By(func(any) error {
+			if r.BirthDate == "" {
+				return nil
+			}
+
+			_, err := r.birthDate()
+
+			return err
+		})),
+		validation.Field(&r.Major, validation.Length(0, MaxMajorLen)),
+		validation.Field(&r.Status, validation.In(statuses...)),
 	)
 	if err != nil {
 		return fmt.Errorf("student creation request validation failed %w", err)
 	}
 
-	err = validation.Validate(strings.Fields(r.Name),
-		validation.Each(is.UTFLetter),
-	)
+	return nil
+}
+
+func (r StudentCreate) birthDate() (time.Time, error) {
+	d, err := r.Calendar.ParseDate(r.BirthDate)
 	if err != nil {
-		return fmt.Errorf("student creation request validation failed %w", err)
+		return time.Time{}, err
 	}
 
-	return nil
+	if d.After(time.Now()) {
+		return time.Time{}, ErrFutureBirth
+	}
+
+	if d.Year() < 1900 {
+		return time.Time{}, ErrAncientBirth
+	}
+
+	return d, nil
+}
+
+// Entrance returns the requested entrance, the semester which is running at the given time
+// when there is no entrance year, or the fall semester when there is only an entrance year.
+func (r StudentCreate) Entrance(now time.Time) model.Entrance {
+	if r.EntranceYear == 0 {
+		d, err := jalali.FromTime(now)
+		if err != nil {
+			return model.Entrance{Year: 0, Semester: ""}
+		}
+
+		return model.EntranceOf(d)
+	}
+
+	semester := model.Semester(r.EntranceSemester)
+	if semester == "" {
+		semester = model.SemesterFall
+	}
+
+	return model.Entrance{
+		Year:     r.EntranceYear,
+		Semester: semester,
+	}
+}
+
+// Student returns the student of a valid request which is created at the given time, names are
+// normalized and phone numbers are stored with the country code (e.g. +989121234567).
+func (r StudentCreate) Student(id string, now time.Time) model.Student {
+	phone := r.Phone
+	if phone != "" {
+		phone = "+98" + phone[len(phone)-10:]
+	}
+
+	status := model.StudentStatus(r.Status)
+	if status == "" {
+		status = model.StudentActive
+	}
+
+	var birth time.Time
+	if r.BirthDate != "" {
+		birth, _ = r.birthDate()
+	}
+
+	return model.Student{
+		Name:       names.Normalize(r.Name),
+		ID:         id,
+		Courses:    nil,
+		Entrance:   r.Entrance(now),
+		CreatedAt:  now,
+		Email:      strings.ToLower(r.Email),
+		Phone:      phone,
+		NationalID: r.NationalID,
+		BirthDate:  birth,
+		Major:      r.Major,
+		Status:     status,
+	}
 }
@@ -0,0 +1,73 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/webhook"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+var (
+	ErrWebhookScheme  = errors.New("must be an http or https url")
+	ErrWebhookAddress = errors.New("must not be an internal address")
+)
+
+// webhookURL rejects the urls which are not http(s) and the internal hosts, the names are checked
+// again by the sender after their resolution.
+func webhookURL(value any) error {
+	s, _ := value.(string)
+
+	// the invalid urls are reported by is.URL.
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil // nolint: nilerr
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrWebhookScheme
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookAddress
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !webhook.Allowed(addr) {
+		return ErrWebhookAddress
+	}
+
+	return nil
+}
+
+// Webhook creates or replaces a webhook, an empty secret is generated on creation
+// and kept on update, and a missing active is considered as true.
+type Webhook struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+func (r Webhook) Validate() error {
+	types := make([]any, 0, len(event.Types()))
+	for _, t := range event.Types() {
+		types = append(types, string(t))
+	}
+
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.URL, validation.Required, is.URL, validation.By(webhookURL)),
+		validation.Field(&r.Secret, validation.Length(16, 0)),
+		validation.Field(&r.Events, validation.Each(validation.In(types...))),
+	)
+	if err != nil {
+		return fmt.Errorf("webhook request validation failed %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package response
+
+import "github.com/1995parham-teaching/students/internal/model"
+
+// APIKey is a created or rotated api key with the key itself, it is not shown again.
+type APIKey struct {
+	model.APIKey
+
+	Key string `json:"key"`
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/jalali"
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+// Enrollment is an enrollment with its times rendered in the requested calendar and location,
+// the rendered times shadow the times of the model.
+type Enrollment struct {
+	model.Enrollment
+
+	// EnrolledAt is nil for the enrollments which are older than their registration time.
+	EnrolledAt *string `json:"enrolled_at"`
+	DroppedAt  string  `json:"dropped_at,omitempty"`
+}
+
+func NewEnrollment(e model.Enrollment, cal jalali.Calendar, loc *time.Location) Enrollment {
+	r := Enrollment{
+		Enrollment: e,
+		EnrolledAt: nil,
+		DroppedAt:  "",
+	}
+
+	if e.EnrolledAt != nil {
+		at := cal.Format(e.EnrolledAt.In(loc))
+		r.EnrolledAt = &at
+	}
+
+	if e.DroppedAt != nil {
+		r.DroppedAt = cal.Format(e.DroppedAt.In(loc))
+	}
+
+	return r
+}
+
+func NewEnrollments(es []model.Enrollment, cal jalali.Calendar, loc *time.Location) []Enrollment {
+	enrollments := make([]Enrollment, 0, len(es))
+
+	for _, e := range es {
+		enrollments = append(enrollments, NewEnrollment(e, cal, loc))
+	}
+
+	return enrollments
+}
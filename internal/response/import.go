@@ -0,0 +1,18 @@
+package response
+
+// Import reports the result of a bulk import, each row of the uploaded
+// file is reported with its line number.
+type Import struct {
+	DryRun  bool        `json:"dry_run"`
+	Atomic  bool        `json:"atomic"`
+	Total   int         `json:"total"`
+	Created int         `json:"created"`
+	Failed  int         `json:"failed"`
+	Rows    []ImportRow `json:"rows"`
+}
+
+type ImportRow struct {
+	Line  int    `json:"line"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
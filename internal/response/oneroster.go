@@ -0,0 +1,20 @@
+package response
+
+// OneRoster reports the result of a OneRoster bundle import, errors are prefixed
+// with their file name and line number (e.g. users.csv:3).
+type OneRoster struct {
+	Users       OneRosterCount `json:"users"`
+	Classes     OneRosterCount `json:"classes"`
+	Enrollments OneRosterCount `json:"enrollments"`
+	Errors      []string       `json:"errors"`
+}
+
+// OneRosterCount counts rows which are created, matched with an existing entity
+// using their sourced id, deleted (only the enrollments with the tobedeleted status)
+// or skipped (e.g. non-student users).
+type OneRosterCount struct {
+	Created int `json:"created"`
+	Matched int `json:"matched"`
+	Deleted int `json:"deleted"`
+	Skipped int `json:"skipped"`
+}
@@ -0,0 +1,43 @@
+package response
+
+import (
+	"context"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/jalali"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/privacy"
+)
+
+// Student is a student with its times rendered in the requested calendar and location,
+// the rendered times shadow the times of the model.
+type Student struct {
+	model.Student
+
+	CreatedAt string `json:"created_at"`
+	BirthDate string `json:"birth_date,omitempty"`
+}
+
+// NewStudent renders the student, its personally identifiable information is hidden
+// when the principal of the context cannot see it.
+func NewStudent(ctx context.Context, st model.Student, cal jalali.Calendar, loc *time.Location) Student {
+	if !privacy.PII(ctx, st.ID) {
+		st = st.Redacted()
+	}
+
+	return Student{
+		Student:   st,
+		CreatedAt: cal.Format(st.CreatedAt.In(loc)),
+		BirthDate: cal.FormatDate(st.BirthDate),
+	}
+}
+
+func NewStudents(ctx context.Context, ss []model.Student, cal jalali.Calendar, loc *time.Location) []Student {
+	students := make([]Student, 0, len(ss))
+
+	for _, st := range ss {
+		students = append(students, NewStudent(ctx, st, cal, loc))
+	}
+
+	return students
+}
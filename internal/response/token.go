@@ -0,0 +1,10 @@
+package response
+
+// Token is an access token with its refresh token, ExpiresIn is the lifetime of
+// the access token in seconds.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
@@ -0,0 +1,30 @@
+package seed
+
+// nolint: gochecknoglobals
+var (
+	persianFirstNames = []string{
+		"علی", "محمد", "حسین", "رضا", "مهدی", "امیر", "پرهام", "آرش", "کیان", "سینا",
+		"فاطمه", "زهرا", "مریم", "الهه", "سارا", "نگار", "ریحانه", "مهسا", "پریسا", "نیلوفر",
+	}
+	persianLastNames = []string{
+		"علوانی", "احمدی", "محمدی", "حسینی", "رضایی", "کریمی", "موسوی", "جعفری", "صادقی", "دستان",
+		"رحیمی", "اکبری", "نوری", "کاظمی", "قاسمی", "شریفی", "تهرانی", "اصفهانی", "شیرازی", "یزدانی",
+	}
+	englishFirstNames = []string{
+		"Parham", "Elahe", "Ali", "Sara", "Reza", "Maryam", "Amir", "Negar", "Kian", "Zahra",
+		"Emma", "Liam", "Olivia", "Noah", "Sophia", "James", "Ava", "Lucas", "Mia", "Ethan",
+	}
+	englishLastNames = []string{
+		"Alvani", "Dastan", "Ahmadi", "Mohammadi", "Hosseini", "Karimi", "Mousavi", "Sadeghi", "Rahimi", "Tehrani",
+		"Smith", "Johnson", "Brown", "Miller", "Davis", "Wilson", "Taylor", "Anderson", "Thomas", "Moore",
+	}
+	persianCourses = []string{
+		"مهندسی اینترنت", "پایگاه داده", "سیستم عامل", "شبکه های کامپیوتری", "ساختمان داده",
+		"طراحی الگوریتم", "هوش مصنوعی", "معماری کامپیوتر", "نظریه زبان ها و ماشین ها", "مهندسی نرم افزار",
+	}
+	englishCourses = []string{
+		"Internet Engineering", "Database Design", "Operating Systems", "Computer Networks", "Data Structures",
+		"Algorithm Design", "Artificial Intelligence", "Computer Architecture", "Compiler Design", "Software Engineering",
+		"Discrete Mathematics", "Linear Algebra", "Cloud Computing", "Information Retrieval", "Computer Graphics",
+	}
+)
@@ -0,0 +1,197 @@
+// Package seed fills the stores with synthetic students, courses and enrollments for demos and load tests.
+// Data is generated from a seeded source, so the same seed always generates the same data.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/handler"
+	"github.com/1995parham-teaching/students/internal/idgen"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/request"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+)
+
+const (
+	// maxAttempts is the number of generated identifiers which are tried before giving up,
+	// identifiers may collide with the existing ones.
+	maxAttempts = 10
+
+	// zipfS shapes the popularity of courses, a few courses have most of the students.
+	zipfS = 1.2
+
+	// entranceYears is the number of the academic years in which the students are admitted.
+	entranceYears = 6
+)
+
+var ErrTooManyCollisions = errors.New("cannot find a free identifier")
+
+// Reference is the default reference time of the generated data.
+// nolint: gochecknoglobals
+var Reference = time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+type Seeder struct {
+	Students student.Student
+	Courses  course.Course
+	Rand     *rand.Rand
+	// Persian is the ratio of the names which are generated in Persian.
+	Persian float64
+	// Now is the reference time of the generated data (e.g. the creation time and the entrance
+	// of the students) instead of the current time, so the same seed always generates the same data.
+	Now time.Time
+}
+
+type Result struct {
+	Students    int
+	Courses     int
+	Enrollments int
+}
+
+func New(students student.Student, courses course.Course, seed uint64, persian float64, now time.Time) Seeder {
+	return Seeder{
+		Students: students,
+		Courses:  courses,
+		Rand:     rand.New(rand.NewPCG(seed, seed)), // nolint: gosec
+		Persian:  persian,
+		Now:      now,
+	}
+}
+
+// Run creates the given number of students and courses then registers each student into
+// at most maxCourses distinct courses.
+func (s Seeder) Run(ctx context.Context, students int, courses int, maxCourses int) (Result, error) {
+	var r Result
+
+	cids := make([]string, 0, courses)
+
+	for range courses {
+		c, err := s.course(ctx)
+		if err != nil {
+			return r, err
+		}
+
+		cids = append(cids, c.ID)
+		r.Courses++
+	}
+
+	maxCourses = min(maxCourses, len(cids))
+
+	var popularity *rand.Zipf
+	if len(cids) > 1 {
+		popularity = rand.NewZipf(s.Rand, zipfS, 1, uint64(len(cids)-1))
+	}
+
+	for range students {
+		st, err := s.student(ctx)
+		if err != nil {
+			return r, err
+		}
+
+		r.Students++
+
+		if maxCourses == 0 {
+			continue
+		}
+
+		n := s.Rand.IntN(maxCourses + 1)
+		taken := make(map[int]struct{}, n)
+
+		for len(taken) < n {
+			i := 0
+			if popularity != nil {
+				i = int(popularity.Uint64())
+			}
+
+			// popular courses are taken already, so pick one uniformly.
+			if _, ok := taken[i]; ok {
+				i = s.Rand.IntN(len(cids))
+			}
+
+			if _, ok := taken[i]; ok {
+				continue
+			}
+
+			taken[i] = struct{}{}
+
+			err := s.Students.Register(ctx, st.ID, cids[i])
+			if err != nil {
+				return r, err
+			}
+
+			r.Enrollments++
+		}
+	}
+
+	return r, nil
+}
+
+func (s Seeder) student(ctx context.Context) (model.Student, error) {
+	first, last := englishFirstNames, englishLastNames
+	if s.Rand.Float64() < s.Persian {
+		first, last = persianFirstNames, persianLastNames
+	}
+
+	// students are admitted in the fall semesters of the recent years.
+	entrance := request.StudentCreate{}.Entrance(s.Now) // nolint: exhaustruct
+
+	// nolint: exhaustruct
+	req := request.StudentCreate{
+		Name:             first[s.Rand.IntN(len(first))] + " " + last[s.Rand.IntN(len(last))],
+		EntranceYear:     entrance.Year - s.Rand.IntN(entranceYears),
+		EntranceSemester: string(model.SemesterFall),
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return model.Student{}, err
+	}
+
+	for range maxAttempts {
+		st := req.Student(idgen.NewFrom(s.Rand, handler.StudentIDMax), s.Now)
+
+		err := s.Students.Create(ctx, st)
+		if errors.Is(err, student.ErrStudentAlreadyExists) {
+			continue
+		}
+
+		return st, err
+	}
+
+	return model.Student{}, fmt.Errorf("student %w", ErrTooManyCollisions)
+}
+
+func (s Seeder) course(ctx context.Context) (model.Course, error) {
+	names := englishCourses
+	if s.Rand.Float64() < s.Persian {
+		names = persianCourses
+	}
+
+	req := request.CourseCreate{
+		Name:       names[s.Rand.IntN(len(names))],
+		Capacity:   0,
+		Instructor: "",
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return model.Course{}, err
+	}
+
+	for range maxAttempts {
+		c := req.Course(idgen.NewFrom(s.Rand, handler.CourseIDMax))
+
+		err := s.Courses.Create(ctx, c)
+		if errors.Is(err, course.ErrCourseAlreadyExists) {
+			continue
+		}
+
+		return c, err
+	}
+
+	return model.Course{}, fmt.Errorf("course %w", ErrTooManyCollisions)
+}
@@ -0,0 +1,78 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/seed"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func run(t *testing.T) (seed.Result, []model.Student) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{ //nolint:exhaustruct
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	ss := student.NewSQL(db)
+
+	r, err := seed.New(ss, course.NewSQL(db), 1373, 0.5, seed.Reference).Run(context.Background(), 20, 5, 3)
+	if err != nil {
+		t.Fatalf("failed to seed: %v", err)
+	}
+
+	students, err := ss.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get all students: %v", err)
+	}
+
+	return r, students
+}
+
+func TestSeeder_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	r1, s1 := run(t)
+	r2, s2 := run(t)
+
+	if r1 != r2 {
+		t.Fatalf("expected the same result, got %+v and %+v", r1, r2)
+	}
+
+	if r1.Students != 20 || r1.Courses != 5 {
+		t.Errorf("expected 20 students and 5 courses, got %+v", r1)
+	}
+
+	if len(s1) != len(s2) {
+		t.Fatalf("expected the same number of students, got %d and %d", len(s1), len(s2))
+	}
+
+	enrollments := 0
+
+	for i := range s1 {
+		if s1[i].ID != s2[i].ID || s1[i].Name != s2[i].Name || !s1[i].CreatedAt.Equal(s2[i].CreatedAt) ||
+			s1[i].Entrance != s2[i].Entrance {
+			t.Errorf("expected the same student, got %+v and %+v", s1[i], s2[i])
+		}
+
+		if len(s1[i].Courses) > 3 {
+			t.Errorf("expected at most 3 courses, got %d", len(s1[i].Courses))
+		}
+
+		enrollments += len(s1[i].Courses)
+	}
+
+	if enrollments != r1.Enrollments {
+		t.Errorf("expected %d enrollments, got %d", r1.Enrollments, enrollments)
+	}
+}
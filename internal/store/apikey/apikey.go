@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+// LastUsedPrecision is how often the last use of a key is written, so the authenticated
+// requests don't write on each request.
+const LastUsedPrecision = time.Minute
+
+var ErrAPIKeyNotFound = errors.New("api key does not exist")
+
+// APIKey keeps the api keys with the hash of their secrets.
+type APIKey interface {
+	Create(ctx context.Context, k model.APIKey) error
+	GetAll(ctx context.Context) ([]model.APIKey, error)
+	Get(ctx context.Context, id string) (model.APIKey, error)
+	// Rotate replaces the hash and the expiry of the key, the old secret stops working immediately.
+	Rotate(ctx context.Context, id string, hash []byte, expiresAt *time.Time) (model.APIKey, error)
+	Delete(ctx context.Context, id string) error
+	// Touch records the use of the key at the given time, with LastUsedPrecision.
+	Touch(ctx context.Context, id string, at time.Time) error
+}
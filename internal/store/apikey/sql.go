@@ -0,0 +1,155 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SQLItem struct {
+	ID         string `gorm:"primaryKey"`
+	Name       string
+	Hash       []byte
+	Scopes     string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RotatedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (SQLItem) TableName() string {
+	return "api_keys"
+}
+
+type SQL struct {
+	db *gorm.DB
+}
+
+func NewSQL(db *gorm.DB) APIKey {
+	err := db.AutoMigrate(new(SQLItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return SQL{
+		db: db,
+	}
+}
+
+func toModel(item SQLItem) model.APIKey {
+	scopes := []string{}
+	if item.Scopes != "" {
+		scopes = strings.Split(item.Scopes, ",")
+	}
+
+	return model.APIKey{
+		ID:         item.ID,
+		Name:       item.Name,
+		Hash:       item.Hash,
+		Scopes:     scopes,
+		ExpiresAt:  item.ExpiresAt,
+		LastUsedAt: item.LastUsedAt,
+		RotatedAt:  item.RotatedAt,
+		CreatedAt:  item.CreatedAt,
+	}
+}
+
+// utc converts the optional time into utc, like the other times which are stored.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+
+	return &u
+}
+
+func (sql SQL) Create(ctx context.Context, k model.APIKey) error {
+	item := SQLItem{
+		ID:         k.ID,
+		Name:       k.Name,
+		Hash:       k.Hash,
+		Scopes:     strings.Join(k.Scopes, ","),
+		ExpiresAt:  utc(k.ExpiresAt),
+		LastUsedAt: nil,
+		RotatedAt:  nil,
+		CreatedAt:  k.CreatedAt.UTC(),
+	}
+
+	return gorm.G[SQLItem](sql.db).Create(ctx, &item)
+}
+
+func (sql SQL) GetAll(ctx context.Context) ([]model.APIKey, error) {
+	items, err := gorm.G[SQLItem](sql.db).Order("created_at, id").Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]model.APIKey, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, toModel(item))
+	}
+
+	return keys, nil
+}
+
+func (sql SQL) Get(ctx context.Context, id string) (model.APIKey, error) {
+	item, err := gorm.G[SQLItem](sql.db).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.APIKey{}, ErrAPIKeyNotFound
+		}
+
+		return model.APIKey{}, err
+	}
+
+	return toModel(item), nil
+}
+
+func (sql SQL) Rotate(ctx context.Context, id string, hash []byte, expiresAt *time.Time) (model.APIKey, error) {
+	rows, err := gorm.G[SQLItem](sql.db).Where("id = ?", id).Set(clause.Assignments(map[string]any{
+		"hash":       hash,
+		"expires_at": utc(expiresAt),
+		"rotated_at": time.Now().UTC(),
+	})).Update(ctx)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+
+	if rows == 0 {
+		return model.APIKey{}, ErrAPIKeyNotFound
+	}
+
+	return sql.Get(ctx, id)
+}
+
+func (sql SQL) Delete(ctx context.Context, id string) error {
+	rows, err := gorm.G[SQLItem](sql.db).Where("id = ?", id).Delete(ctx)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (sql SQL) Touch(ctx context.Context, id string, at time.Time) error {
+	at = at.UTC()
+
+	_, err := gorm.G[SQLItem](sql.db).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-LastUsedPrecision)).
+		Set(clause.Assignments(map[string]any{"last_used_at": at})).
+		Update(ctx)
+
+	return err
+}
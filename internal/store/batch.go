@@ -0,0 +1,18 @@
+package store
+
+import "fmt"
+
+// BatchError reports the item of a batch operation which caused
+// the whole batch to be rolled back.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e BatchError) Error() string {
+	return fmt.Sprintf("batch item %d failed: %s", e.Index, e.Err)
+}
+
+func (e BatchError) Unwrap() error {
+	return e.Err
+}
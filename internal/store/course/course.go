@@ -10,10 +10,18 @@ import (
 var (
 	ErrCourseAlreadyExists = errors.New("course already exists")
 	ErrCourseNotFound      = errors.New("course does not exist")
+	ErrCourseFull          = errors.New("course has reached its capacity")
 )
 
 type Course interface {
 	GetAll(ctx context.Context) ([]model.Course, error)
 	Create(ctx context.Context, course model.Course) error
+	// CreateAll creates all the given courses in a single transaction,
+	// store.BatchError reports the course which caused the rollback.
+	CreateAll(ctx context.Context, courses []model.Course) error
 	Get(ctx context.Context, id string) (model.Course, error)
+	// Meetings returns the weekly meetings of the course in the order they are set.
+	Meetings(ctx context.Context, id string) ([]model.Meeting, error)
+	// SetMeetings replaces the weekly meetings of the course.
+	SetMeetings(ctx context.Context, id string, meetings []model.Meeting) error
 }
@@ -0,0 +1,94 @@
+package course
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1995parham-teaching/students/internal/metrics"
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+const metricsName = "course"
+
+// Metered decorates a course store and records latency, errors and in-flight
+// calls of each method.
+type Metered struct {
+	Next    Course
+	Metrics metrics.Store
+}
+
+func NewMetered(next Course, m metrics.Store) Course {
+	return Metered{
+		Next:    next,
+		Metrics: m,
+	}
+}
+
+func (m Metered) GetAll(ctx context.Context) ([]model.Course, error) {
+	done := m.Metrics.Start(metricsName, "GetAll")
+
+	cs, err := m.Next.GetAll(ctx)
+	done(errorLabel(err))
+
+	return cs, err
+}
+
+func (m Metered) Create(ctx context.Context, c model.Course) error {
+	done := m.Metrics.Start(metricsName, "Create")
+
+	err := m.Next.Create(ctx, c)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) CreateAll(ctx context.Context, courses []model.Course) error {
+	done := m.Metrics.Start(metricsName, "CreateAll")
+
+	err := m.Next.CreateAll(ctx, courses)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) Get(ctx context.Context, id string) (model.Course, error) {
+	done := m.Metrics.Start(metricsName, "Get")
+
+	c, err := m.Next.Get(ctx, id)
+	done(errorLabel(err))
+
+	return c, err
+}
+
+func (m Metered) Meetings(ctx context.Context, id string) ([]model.Meeting, error) {
+	done := m.Metrics.Start(metricsName, "Meetings")
+
+	ms, err := m.Next.Meetings(ctx, id)
+	done(errorLabel(err))
+
+	return ms, err
+}
+
+func (m Metered) SetMeetings(ctx context.Context, id string, meetings []model.Meeting) error {
+	done := m.Metrics.Start(metricsName, "SetMeetings")
+
+	err := m.Next.SetMeetings(ctx, id, meetings)
+	done(errorLabel(err))
+
+	return err
+}
+
+// errorLabel converts domain errors into metric labels, any other error
+// is reported as internal.
+func errorLabel(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrCourseNotFound):
+		return "course_not_found"
+	case errors.Is(err, ErrCourseAlreadyExists):
+		return "course_already_exists"
+	default:
+		return "internal"
+	}
+}
@@ -2,34 +2,60 @@ package course
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"gorm.io/gorm"
 
+	"github.com/1995parham-teaching/students/internal/event"
 	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store"
+	"github.com/1995parham-teaching/students/internal/store/outbox"
 )
 
 type SQLItem struct {
-	ID   string `gorm:"primaryKey"`
-	Name string
+	ID         string `gorm:"primaryKey"`
+	Name       string
+	Capacity   int
+	Instructor string
 }
 
 func (SQLItem) TableName() string {
 	return "courses"
 }
 
+type MeetingItem struct {
+	CourseID string `gorm:"primaryKey"`
+	Position int    `gorm:"primaryKey"`
+	Weekday  string
+	Start    string
+	End      string
+	Location string
+}
+
+func (MeetingItem) TableName() string {
+	return "course_meetings"
+}
+
 type SQL struct {
 	conn gorm.Interface[SQLItem]
+	db   *gorm.DB
 }
 
 func NewSQL(db *gorm.DB) Course {
-	err := db.AutoMigrate(new(SQLItem))
+	err := db.AutoMigrate(new(SQLItem), new(MeetingItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = outbox.Migrate(db)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	return SQL{
 		conn: gorm.G[SQLItem](db),
+		db:   db,
 	}
 }
 
@@ -43,29 +69,135 @@ func (sql SQL) GetAll(ctx context.Context) ([]model.Course, error) {
 
 	for _, item := range items {
 		courses = append(courses, model.Course{
-			ID:   item.ID,
-			Name: item.Name,
+			ID:         item.ID,
+			Name:       item.Name,
+			Capacity:   item.Capacity,
+			Instructor: item.Instructor,
 		})
 	}
 
 	return courses, nil
 }
 
-func (sql SQL) Create(ctx context.Context, s model.Course) error {
-	return sql.conn.Create(ctx, &SQLItem{
-		ID:   s.ID,
-		Name: s.Name,
+func (sql SQL) Create(ctx context.Context, c model.Course) error {
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return create(ctx, tx, c)
+	})
+}
+
+// create inserts the course and its CourseCreated event using the given transaction.
+func create(ctx context.Context, tx *gorm.DB, c model.Course) error {
+	err := gorm.G[SQLItem](tx).Create(ctx, &SQLItem{
+		ID:         c.ID,
+		Name:       c.Name,
+		Capacity:   c.Capacity,
+		Instructor: c.Instructor,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrCourseAlreadyExists
+		}
+
+		return err
+	}
+
+	e, err := event.New(event.CourseCreated, event.Course{
+		ID:   c.ID,
+		Name: c.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return outbox.Put(ctx, tx, e)
+}
+
+func (sql SQL) CreateAll(ctx context.Context, courses []model.Course) error {
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, c := range courses {
+			err := create(ctx, tx, c)
+			if err != nil {
+				return store.BatchError{Index: i, Err: err}
+			}
+		}
+
+		return nil
 	})
 }
 
 func (sql SQL) Get(ctx context.Context, id string) (model.Course, error) {
 	c, err := sql.conn.Where("id = ?", id).First(ctx)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Course{}, ErrCourseNotFound
+		}
+
 		return model.Course{}, err
 	}
 
 	return model.Course{
-		ID:   c.ID,
-		Name: c.Name,
+		ID:         c.ID,
+		Name:       c.Name,
+		Capacity:   c.Capacity,
+		Instructor: c.Instructor,
 	}, nil
 }
+
+func (sql SQL) Meetings(ctx context.Context, id string) ([]model.Meeting, error) {
+	_, err := sql.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := gorm.G[MeetingItem](sql.db).Where("course_id = ?", id).Order("position").Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meetings := make([]model.Meeting, 0, len(items))
+
+	for _, item := range items {
+		meetings = append(meetings, model.Meeting{
+			Weekday:  item.Weekday,
+			Start:    item.Start,
+			End:      item.End,
+			Location: item.Location,
+		})
+	}
+
+	return meetings, nil
+}
+
+func (sql SQL) SetMeetings(ctx context.Context, id string, meetings []model.Meeting) error {
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := gorm.G[SQLItem](tx).Where("id = ?", id).First(ctx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCourseNotFound
+			}
+
+			return err
+		}
+
+		_, err = gorm.G[MeetingItem](tx).Where("course_id = ?", id).Delete(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i, m := range meetings {
+			err := gorm.G[MeetingItem](tx).Create(ctx, &MeetingItem{
+				CourseID: id,
+				Position: i,
+				Weekday:  m.Weekday,
+				Start:    m.Start,
+				End:      m.End,
+				Location: m.Location,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
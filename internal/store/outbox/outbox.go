@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+)
+
+// Message is an undelivered event with the number of its failed deliveries.
+type Message struct {
+	Event    event.Event
+	Attempts int
+	// Delivered are the names of the sinks which have accepted the event in the failed deliveries,
+	// so it is retried only on the other sinks.
+	Delivered []string
+}
+
+// Outbox keeps events until they are delivered. Events are written by the stores
+// in the same transaction as their change with Put.
+type Outbox interface {
+	// Pending returns at most limit undelivered events which are due, ordered by their id.
+	Pending(ctx context.Context, limit int) ([]Message, error)
+	MarkDelivered(ctx context.Context, id uint64) error
+	// MarkFailed records a failed delivery with the sinks which have accepted the event,
+	// the event is retried on the other sinks after the given time.
+	MarkFailed(ctx context.Context, id uint64, delivered []string, cause error, retryAt time.Time) error
+	// Prune removes the events which are delivered before the given time and returns their number.
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
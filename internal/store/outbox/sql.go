@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SQLItem struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	Type        string
+	Payload     []byte
+	CreatedAt   time.Time
+	DeliveredAt *time.Time `gorm:"index"`
+	Attempts    int
+	LastError   string
+	RetryAt     time.Time
+	// Sinks is the json list of the sinks which have accepted the event before its delivery to all of them.
+	Sinks string
+}
+
+func (SQLItem) TableName() string {
+	return "outbox"
+}
+
+// Migrate creates the outbox table, stores which write events call it on creation.
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(new(SQLItem))
+}
+
+// Put writes the event into the outbox using the given transaction.
+func Put(ctx context.Context, tx *gorm.DB, e event.Event) error {
+	return gorm.G[SQLItem](tx).Create(ctx, &SQLItem{
+		ID:          0,
+		Type:        string(e.Type),
+		Payload:     e.Payload,
+		CreatedAt:   e.CreatedAt,
+		DeliveredAt: nil,
+		Attempts:    0,
+		LastError:   "",
+		RetryAt:     e.CreatedAt,
+		Sinks:       "",
+	})
+}
+
+type SQL struct {
+	conn gorm.Interface[SQLItem]
+}
+
+func NewSQL(db *gorm.DB) (Outbox, error) {
+	err := Migrate(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return SQL{
+		conn: gorm.G[SQLItem](db),
+	}, nil
+}
+
+func (sql SQL) Pending(ctx context.Context, limit int) ([]Message, error) {
+	items, err := sql.conn.Where("delivered_at IS NULL AND retry_at <= ?", time.Now().UTC()).
+		Order("id").Limit(limit).Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, 0, len(items))
+
+	for _, item := range items {
+		var delivered []string
+
+		if item.Sinks != "" {
+			err := json.Unmarshal([]byte(item.Sinks), &delivered)
+			if err != nil {
+				return nil, fmt.Errorf("sinks of event %d are invalid %w", item.ID, err)
+			}
+		}
+
+		messages = append(messages, Message{
+			Event: event.Event{
+				ID:        item.ID,
+				Tenant:    "",
+				Type:      event.Type(item.Type),
+				Payload:   item.Payload,
+				CreatedAt: item.CreatedAt,
+			},
+			Attempts:  item.Attempts,
+			Delivered: delivered,
+		})
+	}
+
+	return messages, nil
+}
+
+func (sql SQL) MarkDelivered(ctx context.Context, id uint64) error {
+	_, err := sql.conn.Where("id = ?", id).Update(ctx, "delivered_at", time.Now().UTC())
+
+	return err
+}
+
+func (sql SQL) MarkFailed(ctx context.Context, id uint64, delivered []string, cause error, retryAt time.Time) error {
+	sinks, err := json.Marshal(delivered)
+	if err != nil {
+		return err
+	}
+
+	_, err = sql.conn.Where("id = ?", id).Set(clause.Assignments(map[string]any{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": cause.Error(),
+		"retry_at":   retryAt.UTC(),
+		"sinks":      string(sinks),
+	})).Update(ctx)
+
+	return err
+}
+
+func (sql SQL) Prune(ctx context.Context, before time.Time) (int, error) {
+	return sql.conn.Where("delivered_at < ?", before.UTC()).Delete(ctx)
+}
@@ -0,0 +1,28 @@
+package sourcedid
+
+import (
+	"context"
+	"errors"
+)
+
+// Kind is the type of the entity which is identified by a sourced id,
+// sourced ids are only unique for a single kind.
+type Kind string
+
+const (
+	KindUser       Kind = "user"
+	KindClass      Kind = "class"
+	KindEnrollment Kind = "enrollment"
+)
+
+var ErrSourcedIDNotFound = errors.New("sourced id does not exist")
+
+// SourcedID keeps the mapping between the identifiers of external systems (e.g. OneRoster sourced ids)
+// and the local identifiers, so they can survive an import/export round trip.
+type SourcedID interface {
+	// Local returns the local identifier of the given sourced id.
+	Local(ctx context.Context, kind Kind, sourcedID string) (string, error)
+	// All returns a map from local identifiers to their sourced id.
+	All(ctx context.Context, kind Kind) (map[string]string, error)
+	Put(ctx context.Context, kind Kind, sourcedID string, localID string) error
+}
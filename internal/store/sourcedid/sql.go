@@ -0,0 +1,73 @@
+package sourcedid
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SQLItem struct {
+	Kind      string `gorm:"primaryKey"`
+	SourcedID string `gorm:"primaryKey"`
+	LocalID   string `gorm:"index"`
+}
+
+func (SQLItem) TableName() string {
+	return "sourced_ids"
+}
+
+type SQL struct {
+	db *gorm.DB
+}
+
+func NewSQL(db *gorm.DB) SourcedID {
+	err := db.AutoMigrate(new(SQLItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return SQL{
+		db: db,
+	}
+}
+
+func (sql SQL) Local(ctx context.Context, kind Kind, sourcedID string) (string, error) {
+	item, err := gorm.G[SQLItem](sql.db).Where("kind = ? AND sourced_id = ?", kind, sourcedID).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrSourcedIDNotFound
+		}
+
+		return "", err
+	}
+
+	return item.LocalID, nil
+}
+
+func (sql SQL) All(ctx context.Context, kind Kind) (map[string]string, error) {
+	items, err := gorm.G[SQLItem](sql.db).Where("kind = ?", kind).Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(items))
+	for _, item := range items {
+		ids[item.LocalID] = item.SourcedID
+	}
+
+	return ids, nil
+}
+
+func (sql SQL) Put(ctx context.Context, kind Kind, sourcedID string, localID string) error {
+	return gorm.G[SQLItem](sql.db, clause.OnConflict{ // nolint: exhaustruct
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "sourced_id"}}, // nolint: exhaustruct
+		DoUpdates: clause.AssignmentColumns([]string{"local_id"}),
+	}).Create(ctx, &SQLItem{
+		Kind:      string(kind),
+		SourcedID: sourcedID,
+		LocalID:   localID,
+	})
+}
@@ -0,0 +1,182 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"gorm.io/gorm"
+)
+
+// courseRow is the scan target of the per course enrollment queries.
+type courseRow struct {
+	ID         string
+	Name       string
+	Capacity   int
+	Instructor string
+	Enrolled   int
+}
+
+func (r courseRow) stats() model.CourseStats {
+	var rate *float64
+
+	if r.Capacity > 0 {
+		v := float64(r.Enrolled) / float64(r.Capacity)
+		rate = &v
+	}
+
+	return model.CourseStats{
+		Course: model.Course{
+			ID:         r.ID,
+			Name:       r.Name,
+			Capacity:   r.Capacity,
+			Instructor: r.Instructor,
+		},
+		Enrolled: r.Enrolled,
+		FillRate: rate,
+	}
+}
+
+const perCourseQuery = "SELECT `courses`.`id`, `courses`.`name`, " +
+	// the columns which are added to the courses table are null on the older rows.
+	"COALESCE(`courses`.`capacity`, 0) AS `capacity`, COALESCE(`courses`.`instructor`, '') AS `instructor`, " +
+	"COUNT(`students_courses`.`sql_item_id`) AS `enrolled` " +
+	"FROM `courses` LEFT JOIN `students_courses` ON `students_courses`.`course_id` = `courses`.`id` "
+
+const loadQuery = "SELECT `n` AS `courses`, COUNT(*) AS `students` FROM (" +
+	"SELECT COUNT(`students_courses`.`course_id`) AS `n` FROM `students` " +
+	"LEFT JOIN `students_courses` ON `students_courses`.`sql_item_id` = `students`.`id` " +
+	"GROUP BY `students`.`id`) GROUP BY `n` ORDER BY `n`"
+
+const pairsQuery = "SELECT `a`.`course_id` AS `first_id`, `fc`.`name` AS `first_name`, " +
+	"`b`.`course_id` AS `second_id`, `sc`.`name` AS `second_name`, COUNT(*) AS `students` " +
+	"FROM `students_courses` `a` " +
+	"JOIN `students_courses` `b` ON `a`.`sql_item_id` = `b`.`sql_item_id` AND `a`.`course_id` < `b`.`course_id` " +
+	"JOIN `courses` `fc` ON `fc`.`id` = `a`.`course_id` " +
+	"JOIN `courses` `sc` ON `sc`.`id` = `b`.`course_id` " +
+	"GROUP BY `a`.`course_id`, `b`.`course_id` " +
+	"ORDER BY `students` DESC, `a`.`course_id`, `b`.`course_id` LIMIT ?"
+
+type SQL struct {
+	db *gorm.DB
+}
+
+func NewSQL(db *gorm.DB) Stats {
+	return SQL{
+		db: db,
+	}
+}
+
+func (sql SQL) Summary(ctx context.Context, pairs int) (model.Stats, error) {
+	var totals struct {
+		Students    int
+		Courses     int
+		Enrollments int
+	}
+
+	err := sql.db.WithContext(ctx).Raw("SELECT " +
+		"(SELECT COUNT(*) FROM `students`) AS `students`, " +
+		"(SELECT COUNT(*) FROM `courses`) AS `courses`, " +
+		"(SELECT COUNT(*) FROM `students_courses`) AS `enrollments`").Scan(&totals).Error
+	if err != nil {
+		return model.Stats{}, err
+	}
+
+	s := model.Stats{
+		Students:    totals.Students,
+		Courses:     totals.Courses,
+		Enrollments: totals.Enrollments,
+		PerCourse:   nil,
+		Load:        nil,
+		Pairs:       nil,
+	}
+
+	s.PerCourse, err = sql.PerCourse(ctx)
+	if err != nil {
+		return model.Stats{}, err
+	}
+
+	s.Load, err = sql.load(ctx)
+	if err != nil {
+		return model.Stats{}, err
+	}
+
+	s.Pairs, err = sql.pairs(ctx, pairs)
+	if err != nil {
+		return model.Stats{}, err
+	}
+
+	return s, nil
+}
+
+func (sql SQL) PerCourse(ctx context.Context) ([]model.CourseStats, error) {
+	var rows []courseRow
+
+	err := sql.db.WithContext(ctx).
+		Raw(perCourseQuery + "GROUP BY `courses`.`id` ORDER BY `enrolled` DESC, `courses`.`id`").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]model.CourseStats, 0, len(rows))
+	for _, r := range rows {
+		stats = append(stats, r.stats())
+	}
+
+	return stats, nil
+}
+
+func (sql SQL) load(ctx context.Context) ([]model.CourseLoad, error) {
+	load := make([]model.CourseLoad, 0)
+
+	err := sql.db.WithContext(ctx).Raw(loadQuery).Scan(&load).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return load, nil
+}
+
+func (sql SQL) pairs(ctx context.Context, limit int) ([]model.CoursePair, error) {
+	var rows []struct {
+		FirstID    string
+		FirstName  string
+		SecondID   string
+		SecondName string
+		Students   int
+	}
+
+	err := sql.db.WithContext(ctx).Raw(pairsQuery, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]model.CoursePair, 0, len(rows))
+	for _, r := range rows {
+		pairs = append(pairs, model.CoursePair{
+			First:    model.Course{ID: r.FirstID, Name: r.FirstName, Capacity: 0, Instructor: ""},
+			Second:   model.Course{ID: r.SecondID, Name: r.SecondName, Capacity: 0, Instructor: ""},
+			Students: r.Students,
+		})
+	}
+
+	return pairs, nil
+}
+
+func (sql SQL) Course(ctx context.Context, id string) (model.CourseStats, error) {
+	var row courseRow
+
+	res := sql.db.WithContext(ctx).
+		Raw(perCourseQuery+"WHERE `courses`.`id` = ? GROUP BY `courses`.`id`", id).
+		Scan(&row)
+	if res.Error != nil {
+		return model.CourseStats{}, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return model.CourseStats{}, course.ErrCourseNotFound
+	}
+
+	return row.stats(), nil
+}
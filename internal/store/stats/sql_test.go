@@ -0,0 +1,131 @@
+package stats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/stats"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setup creates three courses, the first one with capacity of four, and four students:
+// two of them in the first and second courses, one only in the first course and one without course.
+func setup(t *testing.T) stats.Stats {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{ //nolint:exhaustruct
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	ctx := context.Background()
+
+	cs := course.NewSQL(db)
+	ss := student.NewSQL(db)
+
+	courses := []model.Course{
+		{ID: "00000001", Name: "Internet Engineering", Capacity: 4, Instructor: "bahador"},
+		{ID: "00000002", Name: "Operating Systems", Capacity: 0},
+		{ID: "00000003", Name: "Compiler Design", Capacity: 0},
+	}
+
+	err = cs.CreateAll(ctx, courses)
+	if err != nil {
+		t.Fatalf("failed to create courses: %v", err)
+	}
+
+	registrations := map[string][]string{
+		"00000001": {"00000001", "00000002"},
+		"00000002": {"00000001", "00000002"},
+		"00000003": {"00000001"},
+		"00000004": nil,
+	}
+
+	for sid, cids := range registrations {
+		err := ss.Create(ctx, model.Student{ID: sid, Name: "Parham Alvani", Courses: nil})
+		if err != nil {
+			t.Fatalf("failed to create student: %v", err)
+		}
+
+		for _, cid := range cids {
+			err := ss.Register(ctx, sid, cid)
+			if err != nil {
+				t.Fatalf("failed to register student: %v", err)
+			}
+		}
+	}
+
+	return stats.NewSQL(db)
+}
+
+func TestSQL_Summary(t *testing.T) {
+	t.Parallel()
+
+	st := setup(t)
+
+	s, err := st.Summary(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("failed to compute stats: %v", err)
+	}
+
+	if s.Students != 4 || s.Courses != 3 || s.Enrollments != 5 {
+		t.Errorf("expected 4 students, 3 courses and 5 enrollments, got %d, %d and %d",
+			s.Students, s.Courses, s.Enrollments)
+	}
+
+	if len(s.PerCourse) != 3 {
+		t.Fatalf("expected 3 courses, got %d", len(s.PerCourse))
+	}
+
+	first := s.PerCourse[0]
+	if first.Course.ID != "00000001" || first.Enrolled != 3 || first.FillRate == nil || *first.FillRate != 0.75 {
+		t.Errorf("expected first course with 3 students and 0.75 fill rate, got %+v", first)
+	}
+
+	if first.Course.Instructor != "bahador" {
+		t.Errorf("expected the instructor of the first course, got %q", first.Course.Instructor)
+	}
+
+	if s.PerCourse[1].FillRate != nil {
+		t.Errorf("expected no fill rate without capacity, got %v", *s.PerCourse[1].FillRate)
+	}
+
+	load := []model.CourseLoad{{Courses: 0, Students: 1}, {Courses: 1, Students: 1}, {Courses: 2, Students: 2}}
+
+	if len(s.Load) != len(load) {
+		t.Fatalf("expected %v load, got %v", load, s.Load)
+	}
+
+	for i := range load {
+		if s.Load[i] != load[i] {
+			t.Errorf("expected %v load, got %v", load[i], s.Load[i])
+		}
+	}
+
+	if len(s.Pairs) != 1 {
+		t.Fatalf("expected a single pair, got %v", s.Pairs)
+	}
+
+	if p := s.Pairs[0]; p.First.ID != "00000001" || p.Second.ID != "00000002" || p.Students != 2 {
+		t.Errorf("expected two students in the first and second courses, got %+v", p)
+	}
+}
+
+func TestSQL_Course_NotFound(t *testing.T) {
+	t.Parallel()
+
+	st := setup(t)
+
+	_, err := st.Course(context.Background(), "99999999")
+	if !errors.Is(err, course.ErrCourseNotFound) {
+		t.Errorf("expected ErrCourseNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+// Stats computes the enrollment statistics with aggregate queries over the
+// students and courses tables, so it doesn't own any table.
+type Stats interface {
+	// Summary returns all the statistics with at most pairs course pairings.
+	Summary(ctx context.Context, pairs int) (model.Stats, error)
+	// PerCourse returns the enrollment of all the courses, ordered by the number of their students.
+	PerCourse(ctx context.Context) ([]model.CourseStats, error)
+	// Course returns the enrollment of a single course.
+	Course(ctx context.Context, id string) (model.CourseStats, error)
+}
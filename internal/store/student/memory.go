@@ -2,71 +2,299 @@ package student
 
 import (
 	"context"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store"
 )
 
+// inMemoryItem keeps the student without its courses and the identifiers of its courses.
 type inMemoryItem struct {
-	Name    string
+	Student model.Student
 	Courses []string
 }
 
+// InMemory keeps the students in a map, it is safe for concurrent use but it doesn't check
+// the courses and doesn't write the domain events.
 type InMemory struct {
+	lock     sync.RWMutex
 	students map[string]inMemoryItem
 }
 
 func NewInMemory() Student {
 	return &InMemory{
+		lock:     sync.RWMutex{},
 		students: make(map[string]inMemoryItem),
 	}
 }
 
 func (im *InMemory) GetAll(_ context.Context) ([]model.Student, error) {
+	im.lock.RLock()
+	defer im.lock.RUnlock()
+
 	students := make([]model.Student, 0, len(im.students))
 
-	for id, i := range im.students {
-		students = append(students, model.Student{
-			Name:    i.Name,
-			ID:      id,
-			Courses: nil,
-		})
+	for _, i := range im.students {
+		students = append(students, i.Student)
 	}
 
 	return students, nil
 }
 
 func (im *InMemory) Create(_ context.Context, s model.Student) error {
+	im.lock.Lock()
+	defer im.lock.Unlock()
+
+	return im.create(s)
+}
+
+func (im *InMemory) create(s model.Student) error {
 	if _, ok := im.students[s.ID]; ok {
 		return ErrStudentAlreadyExists
 	}
 
+	for _, i := range im.students {
+		if s.NationalID != "" && i.Student.NationalID == s.NationalID {
+			return ErrNationalIDTaken
+		}
+	}
+
 	courses := make([]string, 0)
 
 	for _, c := range s.Courses {
 		courses = append(courses, c.ID)
 	}
 
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now()
+	}
+
+	if s.Status == "" {
+		s.Status = model.StudentActive
+	}
+
+	s.Courses = nil
+
 	im.students[s.ID] = inMemoryItem{
-		Name:    s.Name,
+		Student: s,
 		Courses: courses,
 	}
 
 	return nil
 }
 
-func (im *InMemory) Register(_ context.Context, _ string, _ string) error {
+func (im *InMemory) CreateAll(_ context.Context, students []model.Student) error {
+	im.lock.Lock()
+	defer im.lock.Unlock()
+
+	seen := make(map[string]struct{}, len(students))
+
+	for i, s := range students {
+		_, exists := im.students[s.ID]
+		_, repeated := seen[s.ID]
+
+		if exists || repeated {
+			return store.BatchError{Index: i, Err: ErrStudentAlreadyExists}
+		}
+
+		seen[s.ID] = struct{}{}
+	}
+
+	for _, s := range students {
+		err := im.create(s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (im *InMemory) Register(ctx context.Context, sid string, cid string) error {
+	_, _, err := im.Enroll(ctx, sid, cid)
+
+	return err
+}
+
+// Enroll records the course identifier, the in-memory store doesn't check the courses.
+func (im *InMemory) Enroll(_ context.Context, sid string, cid string) (model.Enrollment, bool, error) {
+	im.lock.Lock()
+	defer im.lock.Unlock()
+
+	s, ok := im.students[sid]
+	if !ok {
+		return model.Enrollment{}, false, ErrStudentNotFound
+	}
+
+	if slices.Contains(s.Courses, cid) {
+		return s.enrollment(cid), false, nil
+	}
+
+	s.Courses = append(s.Courses, cid)
+	im.students[sid] = s
+
+	return s.enrollment(cid), true, nil
+}
+
+func (im *InMemory) Enrollment(_ context.Context, sid string, cid string) (model.Enrollment, error) {
+	im.lock.RLock()
+	defer im.lock.RUnlock()
+
+	s, ok := im.students[sid]
+	if !ok {
+		return model.Enrollment{}, ErrStudentNotFound
+	}
+
+	if !slices.Contains(s.Courses, cid) {
+		return model.Enrollment{}, ErrStudentNotRegistered
+	}
+
+	return s.enrollment(cid), nil
+}
+
+func (im *InMemory) StudentEnrollments(_ context.Context, sid string) ([]model.Enrollment, error) {
+	im.lock.RLock()
+	defer im.lock.RUnlock()
+
+	s, ok := im.students[sid]
+	if !ok {
+		return nil, ErrStudentNotFound
+	}
+
+	es := make([]model.Enrollment, 0, len(s.Courses))
+	for _, cid := range slices.Sorted(slices.Values(s.Courses)) {
+		es = append(es, s.enrollment(cid))
+	}
+
+	return es, nil
+}
+
+func (im *InMemory) Unregister(_ context.Context, sid string, cid string) error {
+	im.lock.Lock()
+	defer im.lock.Unlock()
+
+	s, ok := im.students[sid]
+	if !ok {
+		return ErrStudentNotFound
+	}
+
+	i := slices.Index(s.Courses, cid)
+	if i < 0 {
+		return ErrStudentNotRegistered
+	}
+
+	s.Courses = slices.Delete(slices.Clone(s.Courses), i, i+1)
+	im.students[sid] = s
+
+	return nil
+}
+
+func (im *InMemory) Drop(_ context.Context, sid string, cid string) (model.Enrollment, error) {
+	im.lock.Lock()
+	defer im.lock.Unlock()
+
+	s, ok := im.students[sid]
+	if !ok {
+		return model.Enrollment{}, ErrStudentNotFound
+	}
+
+	i := slices.Index(s.Courses, cid)
+	if i < 0 {
+		return model.Enrollment{}, ErrStudentNotRegistered
+	}
+
+	e := s.enrollment(cid)
+	now := time.Now().UTC()
+
+	e.Status = model.EnrollmentDropped
+	e.DroppedAt = &now
+
+	s.Courses = slices.Delete(slices.Clone(s.Courses), i, i+1)
+	im.students[sid] = s
+
+	return e, nil
+}
+
+// snapshot copies the students, so the callbacks are called without holding the lock.
+func (im *InMemory) snapshot() []inMemoryItem {
+	im.lock.RLock()
+	defer im.lock.RUnlock()
+
+	items := make([]inMemoryItem, 0, len(im.students))
+	for _, s := range im.students {
+		items = append(items, s)
+	}
+
+	return items
+}
+
+func (im *InMemory) Roster(_ context.Context, cid string, fn func(model.Student) error) error {
+	for _, s := range im.snapshot() {
+		if !slices.Contains(s.Courses, cid) {
+			continue
+		}
+
+		err := fn(s.Student)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (im *InMemory) Enrolled(_ context.Context, cid string) (int, error) {
+	im.lock.RLock()
+	defer im.lock.RUnlock()
+
+	count := 0
+
+	for _, s := range im.students {
+		if slices.Contains(s.Courses, cid) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// enrollment returns the enrollment without course name and registration time, because in-memory store
+// only keeps course identifiers.
+func (i inMemoryItem) enrollment(cid string) model.Enrollment {
+	return model.Enrollment{
+		StudentID:   i.Student.ID,
+		StudentName: i.Student.Name,
+		CourseID:    cid,
+		CourseName:  "",
+		Status:      model.EnrollmentEnrolled,
+		EnrolledAt:  nil,
+		DroppedAt:   nil,
+	}
+}
+
+func (im *InMemory) Enrollments(_ context.Context, fn func(model.Enrollment) error) error {
+	for _, s := range im.snapshot() {
+		for _, cid := range s.Courses {
+			err := fn(s.enrollment(cid))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
 func (im *InMemory) Get(_ context.Context, id string) (model.Student, error) {
+	im.lock.RLock()
+	defer im.lock.RUnlock()
+
 	s, ok := im.students[id]
 	if !ok {
 		return model.Student{}, ErrStudentNotFound
 	}
 
-	return model.Student{
-		Name:    s.Name,
-		ID:      id,
-		Courses: nil,
-	}, nil
+	return s.Student, nil
 }
@@ -0,0 +1,76 @@
+package student_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/student"
+)
+
+func TestInMemory_Register(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := student.NewInMemory()
+
+	if err := store.Create(ctx, model.Student{ID: "12345678", Name: "Parham Alvani", Courses: nil}); err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	if err := store.Register(ctx, "12345678", "10101010"); err != nil {
+		t.Fatalf("failed to register student: %v", err)
+	}
+
+	if n, _ := store.Enrolled(ctx, "10101010"); n != 1 {
+		t.Errorf("expected a registered student, got %d", n)
+	}
+
+	if err := store.Unregister(ctx, "12345678", "10101010"); err != nil {
+		t.Fatalf("failed to unregister student: %v", err)
+	}
+
+	if err := store.Unregister(ctx, "12345678", "10101010"); !errors.Is(err, student.ErrStudentNotRegistered) {
+		t.Errorf("expected ErrStudentNotRegistered, got %v", err)
+	}
+
+	if err := store.Register(ctx, "99999999", "10101010"); !errors.Is(err, student.ErrStudentNotFound) {
+		t.Errorf("expected ErrStudentNotFound, got %v", err)
+	}
+}
+
+func TestInMemory_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := student.NewInMemory()
+
+	var wg sync.WaitGroup
+
+	for i := range 20 {
+		wg.Go(func() {
+			id := fmt.Sprintf("%08d", i)
+
+			if err := store.Create(ctx, model.Student{ID: id, Name: "Parham Alvani", Courses: nil}); err != nil {
+				t.Errorf("failed to create student: %v", err)
+
+				return
+			}
+
+			if _, _, err := store.Enroll(ctx, id, "10101010"); err != nil {
+				t.Errorf("failed to enroll student: %v", err)
+			}
+
+			_, _ = store.GetAll(ctx)
+		})
+	}
+
+	wg.Wait()
+
+	if n, _ := store.Enrolled(ctx, "10101010"); n != 20 {
+		t.Errorf("expected 20 enrolled students, got %d", n)
+	}
+}
@@ -0,0 +1,164 @@
+package student
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1995parham-teaching/students/internal/metrics"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/course"
+)
+
+const metricsName = "student"
+
+// Metered decorates a student store and records latency, errors and in-flight
+// calls of each method.
+type Metered struct {
+	Next    Student
+	Metrics metrics.Store
+}
+
+func NewMetered(next Student, m metrics.Store) Student {
+	return Metered{
+		Next:    next,
+		Metrics: m,
+	}
+}
+
+func (m Metered) GetAll(ctx context.Context) ([]model.Student, error) {
+	done := m.Metrics.Start(metricsName, "GetAll")
+
+	ss, err := m.Next.GetAll(ctx)
+	done(errorLabel(err))
+
+	return ss, err
+}
+
+func (m Metered) Create(ctx context.Context, s model.Student) error {
+	done := m.Metrics.Start(metricsName, "Create")
+
+	err := m.Next.Create(ctx, s)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) CreateAll(ctx context.Context, students []model.Student) error {
+	done := m.Metrics.Start(metricsName, "CreateAll")
+
+	err := m.Next.CreateAll(ctx, students)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) Get(ctx context.Context, id string) (model.Student, error) {
+	done := m.Metrics.Start(metricsName, "Get")
+
+	s, err := m.Next.Get(ctx, id)
+	done(errorLabel(err))
+
+	return s, err
+}
+
+func (m Metered) Register(ctx context.Context, sid string, cid string) error {
+	done := m.Metrics.Start(metricsName, "Register")
+
+	err := m.Next.Register(ctx, sid, cid)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) Enroll(ctx context.Context, sid string, cid string) (model.Enrollment, bool, error) {
+	done := m.Metrics.Start(metricsName, "Enroll")
+
+	e, created, err := m.Next.Enroll(ctx, sid, cid)
+	done(errorLabel(err))
+
+	return e, created, err
+}
+
+func (m Metered) Unregister(ctx context.Context, sid string, cid string) error {
+	done := m.Metrics.Start(metricsName, "Unregister")
+
+	err := m.Next.Unregister(ctx, sid, cid)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) Drop(ctx context.Context, sid string, cid string) (model.Enrollment, error) {
+	done := m.Metrics.Start(metricsName, "Drop")
+
+	e, err := m.Next.Drop(ctx, sid, cid)
+	done(errorLabel(err))
+
+	return e, err
+}
+
+func (m Metered) Roster(ctx context.Context, cid string, fn func(model.Student) error) error {
+	done := m.Metrics.Start(metricsName, "Roster")
+
+	err := m.Next.Roster(ctx, cid, fn)
+	done(errorLabel(err))
+
+	return err
+}
+
+func (m Metered) Enrolled(ctx context.Context, cid string) (int, error) {
+	done := m.Metrics.Start(metricsName, "Enrolled")
+
+	count, err := m.Next.Enrolled(ctx, cid)
+	done(errorLabel(err))
+
+	return count, err
+}
+
+func (m Metered) Enrollment(ctx context.Context, sid string, cid string) (model.Enrollment, error) {
+	done := m.Metrics.Start(metricsName, "Enrollment")
+
+	e, err := m.Next.Enrollment(ctx, sid, cid)
+	done(errorLabel(err))
+
+	return e, err
+}
+
+func (m Metered) StudentEnrollments(ctx context.Context, sid string) ([]model.Enrollment, error) {
+	done := m.Metrics.Start(metricsName, "StudentEnrollments")
+
+	es, err := m.Next.StudentEnrollments(ctx, sid)
+	done(errorLabel(err))
+
+	return es, err
+}
+
+func (m Metered) Enrollments(ctx context.Context, fn func(model.Enrollment) error) error {
+	done := m.Metrics.Start(metricsName, "Enrollments")
+
+	err := m.Next.Enrollments(ctx, fn)
+	done(errorLabel(err))
+
+	return err
+}
+
+// errorLabel converts domain errors into metric labels, any other error
+// is reported as internal.
+func errorLabel(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrStudentNotFound):
+		return "student_not_found"
+	case errors.Is(err, ErrStudentAlreadyExists):
+		return "student_already_exists"
+	case errors.Is(err, ErrStudentNotRegistered):
+		return "student_not_registered"
+	case errors.Is(err, course.ErrCourseFull):
+		return "course_full"
+	case errors.Is(err, course.ErrCourseNotFound):
+		return "course_not_found"
+	default:
+		return "internal"
+	}
+}
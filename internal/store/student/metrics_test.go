@@ -0,0 +1,45 @@
+package student_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/metrics"
+	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store/student"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetered_Errors(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.NewStore(prometheus.NewRegistry())
+	store := student.NewMetered(student.NewInMemory(), m)
+	ctx := context.Background()
+
+	st := model.Student{ID: "12345678", Name: "Parham Alvani", Courses: nil}
+
+	if err := store.Create(ctx, st); err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	_ = store.Create(ctx, st)
+	_, _ = store.Get(ctx, "99999999")
+
+	if got := testutil.ToFloat64(m.Errors.WithLabelValues("student", "Create", "student_already_exists")); got != 1 {
+		t.Errorf("expected 1 create error, got %f", got)
+	}
+
+	if got := testutil.ToFloat64(m.Errors.WithLabelValues("student", "Get", "student_not_found")); got != 1 {
+		t.Errorf("expected 1 get error, got %f", got)
+	}
+
+	if got := testutil.CollectAndCount(m.Latency); got != 2 {
+		t.Errorf("expected latency for 2 methods, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(m.InFlight.WithLabelValues("student", "Create")); got != 0 {
+		t.Errorf("expected no in-flight calls, got %f", got)
+	}
+}
@@ -2,31 +2,93 @@ package student
 
 import (
 	"context"
+	stdsql "database/sql"
 	"errors"
 	"log"
+	"time"
 
+	"github.com/1995parham-teaching/students/internal/event"
 	"github.com/1995parham-teaching/students/internal/model"
+	"github.com/1995parham-teaching/students/internal/store"
 	"github.com/1995parham-teaching/students/internal/store/course"
+	"github.com/1995parham-teaching/students/internal/store/outbox"
 	"gorm.io/gorm"
 )
 
 type SQLItem struct {
-	ID      string `gorm:"primaryKey"`
-	Name    string
-	Courses []course.SQLItem `gorm:"many2many:students_courses"`
+	ID               string `gorm:"primaryKey"`
+	Name             string
+	Courses          []course.SQLItem `gorm:"many2many:students_courses"`
+	EntranceYear     int
+	EntranceSemester string
+	CreatedAt        time.Time
+	Email            string
+	Phone            string
+	// NationalID is null for the students without national id, so they don't collide.
+	NationalID *string `gorm:"uniqueIndex"`
+	BirthDate  time.Time
+	Major      string
+	Status     string `gorm:"default:active"`
+}
+
+// student converts the item into a student without courses.
+func (item SQLItem) student() model.Student {
+	nid := ""
+	if item.NationalID != nil {
+		nid = *item.NationalID
+	}
+
+	return model.Student{
+		ID:      item.ID,
+		Name:    item.Name,
+		Courses: nil,
+		Entrance: model.Entrance{
+			Year:     item.EntranceYear,
+			Semester: model.Semester(item.EntranceSemester),
+		},
+		CreatedAt:  item.CreatedAt,
+		Email:      item.Email,
+		Phone:      item.Phone,
+		NationalID: nid,
+		BirthDate:  item.BirthDate,
+		Major:      item.Major,
+		Status:     model.StudentStatus(item.Status),
+	}
 }
 
 func (SQLItem) TableName() string {
 	return "students"
 }
 
+// EnrollmentItem is the join table of the students and their courses.
+type EnrollmentItem struct {
+	SQLItemID string `gorm:"primaryKey"`
+	CourseID  string `gorm:"primaryKey"`
+	// CreatedAt is null for the enrollments which are created before it was kept.
+	CreatedAt *time.Time
+}
+
+func (EnrollmentItem) TableName() string {
+	return "students_courses"
+}
+
 type SQL struct {
 	conn gorm.Interface[SQLItem]
 	db   *gorm.DB
 }
 
 func NewSQL(db *gorm.DB) Student {
-	err := db.AutoMigrate(new(SQLItem))
+	err := db.SetupJoinTable(new(SQLItem), "Courses", new(EnrollmentItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = db.AutoMigrate(new(SQLItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = outbox.Migrate(db)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -50,56 +112,271 @@ func (sql SQL) GetAll(ctx context.Context) ([]model.Student, error) {
 
 		for _, item := range item.Courses {
 			courses = append(courses, model.Course{
-				Name: item.Name,
-				ID:   item.ID,
+				Name:       item.Name,
+				ID:         item.ID,
+				Capacity:   item.Capacity,
+				Instructor: item.Instructor,
 			})
 		}
 
-		students = append(students, model.Student{
-			ID:      item.ID,
-			Name:    item.Name,
-			Courses: courses,
-		})
+		st := item.student()
+		st.Courses = courses
+
+		students = append(students, st)
 	}
 
 	return students, nil
 }
 
 func (sql SQL) Create(ctx context.Context, s model.Student) error {
-	return sql.conn.Create(ctx, &SQLItem{
-		ID:      s.ID,
-		Name:    s.Name,
-		Courses: nil,
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return create(ctx, tx, s)
 	})
 }
 
-func (sql SQL) Register(ctx context.Context, sid string, cid string) error {
-	c, err := gorm.G[course.SQLItem](sql.db).Where("id = ?", cid).First(ctx)
+// create inserts the student and its StudentCreated event using the given transaction,
+// the creation time is set to now when it is zero.
+func create(ctx context.Context, tx *gorm.DB, s model.Student) error {
+	var nid *string
+
+	if s.NationalID != "" {
+		nid = &s.NationalID
+
+		count, err := gorm.G[SQLItem](tx).Where("national_id = ?", s.NationalID).Count(ctx, "id")
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return ErrNationalIDTaken
+		}
+	}
+
+	err := gorm.G[SQLItem](tx).Create(ctx, &SQLItem{
+		ID:               s.ID,
+		Name:             s.Name,
+		Courses:          nil,
+		EntranceYear:     s.Entrance.Year,
+		EntranceSemester: string(s.Entrance.Semester),
+		CreatedAt:        s.CreatedAt,
+		Email:            s.Email,
+		Phone:            s.Phone,
+		NationalID:       nid,
+		BirthDate:        s.BirthDate,
+		Major:            s.Major,
+		Status:           string(s.Status),
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return course.ErrCourseNotFound
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrStudentAlreadyExists
 		}
 
 		return err
 	}
 
-	s, err := sql.conn.Where("id = ?", sid).First(ctx)
+	e, err := event.New(event.StudentCreated, event.Student{
+		ID:   s.ID,
+		Name: s.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return outbox.Put(ctx, tx, e)
+}
+
+func (sql SQL) CreateAll(ctx context.Context, students []model.Student) error {
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, s := range students {
+			err := create(ctx, tx, s)
+			if err != nil {
+				return store.BatchError{Index: i, Err: err}
+			}
+		}
+
+		return nil
+	})
+}
+
+// find checks the existence of both student and course.
+func find(ctx context.Context, tx *gorm.DB, sid string, cid string) (SQLItem, course.SQLItem, error) {
+	c, err := gorm.G[course.SQLItem](tx).Where("id = ?", cid).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return SQLItem{}, course.SQLItem{}, course.ErrCourseNotFound
+		}
+
+		return SQLItem{}, course.SQLItem{}, err
+	}
+
+	s, err := gorm.G[SQLItem](tx).Where("id = ?", sid).First(ctx)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrStudentNotFound
+			return SQLItem{}, course.SQLItem{}, ErrStudentNotFound
 		}
 
+		return SQLItem{}, course.SQLItem{}, err
+	}
+
+	return s, c, nil
+}
+
+// registered checks the existence of the registration.
+func registered(ctx context.Context, tx *gorm.DB, sid string, cid string) (bool, error) {
+	var count int64
+
+	err := tx.WithContext(ctx).Table("students_courses").
+		Where("sql_item_id = ? AND course_id = ?", sid, cid).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Register registers the student into the course when it has room, registering twice is not an error
+// but only the first registration has an event.
+func (sql SQL) Register(ctx context.Context, sid string, cid string) error {
+	_, _, err := sql.Enroll(ctx, sid, cid)
+
+	return err
+}
+
+func (sql SQL) Enroll(ctx context.Context, sid string, cid string) (model.Enrollment, bool, error) {
+	var (
+		e       model.Enrollment
+		created bool
+	)
+
+	err := sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, c, err := find(ctx, tx, sid, cid)
+		if err != nil {
+			return err
+		}
+
+		ok, err := registered(ctx, tx, sid, cid)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			err := register(ctx, tx, sid, c)
+			if err != nil {
+				return err
+			}
+
+			created = true
+		}
+
+		e, err = enrollment(ctx, tx, sid, cid)
+
 		return err
+	})
+	if err != nil {
+		return model.Enrollment{}, false, err
+	}
+
+	return e, created, nil
+}
+
+// register registers the student into the course when it has room.
+func register(ctx context.Context, tx *gorm.DB, sid string, c course.SQLItem) error {
+	if c.Capacity > 0 {
+		var enrolled int64
+
+		err := tx.WithContext(ctx).Table("students_courses").Where("course_id = ?", c.ID).Count(&enrolled).Error
+		if err != nil {
+			return err
+		}
+
+		if enrolled >= int64(c.Capacity) {
+			return course.ErrCourseFull
+		}
+	}
+
+	now := time.Now().UTC()
+
+	err := gorm.G[EnrollmentItem](tx).Create(ctx, &EnrollmentItem{
+		SQLItemID: sid,
+		CourseID:  c.ID,
+		CreatedAt: &now,
+	})
+	if err != nil {
+		return err
+	}
+
+	e, err := event.New(event.StudentRegistered, event.Registration{
+		StudentID: sid,
+		CourseID:  c.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return outbox.Put(ctx, tx, e)
+}
+
+func (sql SQL) Unregister(ctx context.Context, sid string, cid string) error {
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, _, err := find(ctx, tx, sid, cid)
+		if err != nil {
+			return err
+		}
+
+		return unregister(ctx, tx, sid, cid)
+	})
+}
+
+func (sql SQL) Drop(ctx context.Context, sid string, cid string) (model.Enrollment, error) {
+	var e model.Enrollment
+
+	err := sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, _, err := find(ctx, tx, sid, cid)
+		if err != nil {
+			return err
+		}
+
+		e, err = enrollment(ctx, tx, sid, cid)
+		if err != nil {
+			return err
+		}
+
+		return unregister(ctx, tx, sid, cid)
+	})
+	if err != nil {
+		return model.Enrollment{}, err
+	}
+
+	now := time.Now().UTC()
+
+	e.Status = model.EnrollmentDropped
+	e.DroppedAt = &now
+
+	return e, nil
+}
+
+// unregister deletes the registration of the student in the course.
+func unregister(ctx context.Context, tx *gorm.DB, sid string, cid string) error {
+	res := tx.WithContext(ctx).Exec(
+		"DELETE FROM `students_courses` WHERE `sql_item_id` = ? AND `course_id` = ?", sid, cid,
+	)
+	if res.Error != nil {
+		return res.Error
 	}
 
-	s.Courses = append(s.Courses, c)
+	if res.RowsAffected == 0 {
+		return ErrStudentNotRegistered
+	}
 
-	_, err = sql.conn.Updates(ctx, s)
+	e, err := event.New(event.StudentUnregistered, event.Registration{
+		StudentID: sid,
+		CourseID:  cid,
+	})
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return outbox.Put(ctx, tx, e)
 }
 
 func (sql SQL) Get(ctx context.Context, id string) (model.Student, error) {
@@ -109,15 +386,19 @@ func (sql SQL) Get(ctx context.Context, id string) (model.Student, error) {
 	// Here joining will remove the n+1 issue which happens
 	// with Preload().
 	var st []struct {
-		ID          string
-		Name        string
-		CoursesID   *string
-		CoursesName *string
+		SQLItem
+
+		CoursesID         *string
+		CoursesName       *string
+		CoursesCapacity   *int
+		CoursesInstructor *string
 	}
 
 	err := sql.db.Table("students").
 		Joins("LEFT JOIN `students_courses` ON `students`.`id` = `students_courses`.`sql_item_id`").
-		Joins("LEFT JOIN (select id courses_id, name courses_name from `courses`) ON "+
+		// the columns which are added to the courses table are null on the older rows.
+		Joins("LEFT JOIN (select id courses_id, name courses_name, COALESCE(capacity, 0) courses_capacity, "+
+			"COALESCE(instructor, '') courses_instructor from `courses`) ON "+
 			"`courses_id` = `students_courses`.`course_id`").
 		Where("students.id = ?", id).Scan(&st).Error
 	if err != nil {
@@ -133,15 +414,188 @@ func (sql SQL) Get(ctx context.Context, id string) (model.Student, error) {
 	for _, course := range st {
 		if course.CoursesID != nil {
 			courses = append(courses, model.Course{
-				Name: *course.CoursesName,
-				ID:   *course.CoursesID,
+				Name:       *course.CoursesName,
+				ID:         *course.CoursesID,
+				Capacity:   *course.CoursesCapacity,
+				Instructor: *course.CoursesInstructor,
 			})
 		}
 	}
 
-	return model.Student{
-		Name:    st[0].Name,
-		ID:      st[0].ID,
-		Courses: courses,
-	}, nil
+	s := st[0].student()
+	s.Courses = courses
+
+	return s, nil
+}
+
+func (sql SQL) Roster(ctx context.Context, cid string, fn func(model.Student) error) error {
+	_, err := gorm.G[course.SQLItem](sql.db).Where("id = ?", cid).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return course.ErrCourseNotFound
+		}
+
+		return err
+	}
+
+	rows, err := sql.db.WithContext(ctx).Table("students").
+		Select("`students`.`id`, `students`.`name`").
+		Joins("JOIN `students_courses` ON `students`.`id` = `students_courses`.`sql_item_id`").
+		Where("`students_courses`.`course_id` = ?", cid).
+		Order("`students`.`id`").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var st model.Student
+
+		err := rows.Scan(&st.ID, &st.Name)
+		if err != nil {
+			return err
+		}
+
+		err = fn(st)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (sql SQL) Enrolled(ctx context.Context, cid string) (int, error) {
+	_, err := gorm.G[course.SQLItem](sql.db).Where("id = ?", cid).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, course.ErrCourseNotFound
+		}
+
+		return 0, err
+	}
+
+	var count int64
+
+	err = sql.db.WithContext(ctx).Table("students_courses").Where("course_id = ?", cid).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// enrollments returns the rows of the enrollments, ordered by course and student. The conditions are
+// the query and its arguments as in gorm Where, without them all the enrollments are returned.
+func enrollments(ctx context.Context, tx *gorm.DB, conds ...any) (*stdsql.Rows, error) {
+	q := tx.WithContext(ctx).Table("students_courses").
+		Select("`students`.`id`, `students`.`name`, `courses`.`id`, `courses`.`name`, `students_courses`.`created_at`").
+		Joins("JOIN `students` ON `students`.`id` = `students_courses`.`sql_item_id`").
+		Joins("JOIN `courses` ON `courses`.`id` = `students_courses`.`course_id`")
+
+	if len(conds) > 0 {
+		q = q.Where(conds[0], conds[1:]...)
+	}
+
+	return q.Order("`courses`.`id`, `students`.`id`").Rows()
+}
+
+// scanEnrollment scans a row of enrollments.
+func scanEnrollment(rows *stdsql.Rows) (model.Enrollment, error) {
+	e := model.Enrollment{
+		StudentID:   "",
+		StudentName: "",
+		CourseID:    "",
+		CourseName:  "",
+		Status:      model.EnrollmentEnrolled,
+		EnrolledAt:  nil,
+		DroppedAt:   nil,
+	}
+
+	err := rows.Scan(&e.StudentID, &e.StudentName, &e.CourseID, &e.CourseName, &e.EnrolledAt)
+
+	return e, err
+}
+
+// enrollment returns the enrollment of the student in the course.
+func enrollment(ctx context.Context, tx *gorm.DB, sid string, cid string) (model.Enrollment, error) {
+	rows, err := enrollments(ctx, tx, "`students_courses`.`sql_item_id` = ? AND `students_courses`.`course_id` = ?",
+		sid, cid)
+	if err != nil {
+		return model.Enrollment{}, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return model.Enrollment{}, err
+		}
+
+		return model.Enrollment{}, ErrStudentNotRegistered
+	}
+
+	return scanEnrollment(rows)
+}
+
+func (sql SQL) Enrollment(ctx context.Context, sid string, cid string) (model.Enrollment, error) {
+	_, _, err := find(ctx, sql.db, sid, cid)
+	if err != nil {
+		return model.Enrollment{}, err
+	}
+
+	return enrollment(ctx, sql.db, sid, cid)
+}
+
+func (sql SQL) StudentEnrollments(ctx context.Context, sid string) ([]model.Enrollment, error) {
+	_, err := gorm.G[SQLItem](sql.db).Where("id = ?", sid).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+
+		return nil, err
+	}
+
+	rows, err := enrollments(ctx, sql.db, "`students_courses`.`sql_item_id` = ?", sid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	es := make([]model.Enrollment, 0)
+
+	for rows.Next() {
+		e, err := scanEnrollment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, e)
+	}
+
+	return es, rows.Err()
+}
+
+func (sql SQL) Enrollments(ctx context.Context, fn func(model.Enrollment) error) error {
+	rows, err := enrollments(ctx, sql.db)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e, err := scanEnrollment(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
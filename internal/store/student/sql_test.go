@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/1995parham-teaching/students/internal/model"
+	storepkg "github.com/1995parham-teaching/students/internal/store"
 	"github.com/1995parham-teaching/students/internal/store/course"
 	"github.com/1995parham-teaching/students/internal/store/student"
 	"gorm.io/driver/sqlite"
@@ -79,6 +81,112 @@ func TestSQL_Get_ExistingStudent(t *testing.T) {
 	}
 }
 
+func TestSQL_Get_Entrance(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	store := student.NewSQL(db)
+	ctx := context.Background()
+
+	createdAt := time.Date(2022, time.September, 23, 8, 30, 0, 0, time.UTC)
+
+	expected := model.Student{
+		ID:        "12345678",
+		Name:      "Parham Alvani",
+		Courses:   nil,
+		Entrance:  model.Entrance{Year: 1401, Semester: model.SemesterFall},
+		CreatedAt: createdAt,
+	}
+
+	err := store.Create(ctx, expected)
+	if err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	got, err := store.Get(ctx, expected.ID)
+	if err != nil {
+		t.Fatalf("failed to get student: %v", err)
+	}
+
+	if got.Entrance != expected.Entrance {
+		t.Errorf("expected entrance %v, got %v", expected.Entrance, got.Entrance)
+	}
+
+	if !got.CreatedAt.Equal(createdAt) {
+		t.Errorf("expected creation time %s, got %s", createdAt, got.CreatedAt)
+	}
+
+	all, err := store.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("failed to get students: %v", err)
+	}
+
+	if len(all) != 1 || all[0].Entrance != expected.Entrance || !all[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("expected the same entrance and creation time, got %+v", all)
+	}
+}
+
+func TestSQL_Create_Profile(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	store := student.NewSQL(db)
+	ctx := context.Background()
+
+	expected := model.Student{
+		ID:         "12345678",
+		Name:       "Parham Alvani",
+		Courses:    nil,
+		Entrance:   model.Entrance{Year: 1401, Semester: model.SemesterFall},
+		CreatedAt:  time.Date(2022, time.September, 23, 8, 30, 0, 0, time.UTC),
+		Email:      "parham.alvani@gmail.com",
+		Phone:      "+989121234567",
+		NationalID: "0499370899",
+		BirthDate:  time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Major:      "Computer Engineering",
+		Status:     model.StudentGraduated,
+	}
+
+	err := store.Create(ctx, expected)
+	if err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	got, err := store.Get(ctx, expected.ID)
+	if err != nil {
+		t.Fatalf("failed to get student: %v", err)
+	}
+
+	if got.Email != expected.Email || got.Phone != expected.Phone || got.NationalID != expected.NationalID ||
+		!got.BirthDate.Equal(expected.BirthDate) || got.Major != expected.Major || got.Status != expected.Status {
+		t.Errorf("expected profile of %+v, got %+v", expected, got)
+	}
+
+	// students without national id don't collide.
+	for _, id := range []string{"11111111", "22222222"} {
+		err := store.Create(ctx, model.Student{ID: id, Name: "Elahe Dastan", Courses: nil})
+		if err != nil {
+			t.Fatalf("failed to create student without national id: %v", err)
+		}
+	}
+
+	other, err := store.Get(ctx, "11111111")
+	if err != nil {
+		t.Fatalf("failed to get student: %v", err)
+	}
+
+	if other.Status != model.StudentActive {
+		t.Errorf("expected active student by default, got %q", other.Status)
+	}
+
+	expected.ID = "87654321"
+
+	err = store.Create(ctx, expected)
+	if !errors.Is(err, student.ErrNationalIDTaken) {
+		t.Errorf("expected ErrNationalIDTaken, got %v", err)
+	}
+}
+
 func TestSQL_Get_StudentWithCourses(t *testing.T) {
 	t.Parallel()
 
@@ -442,6 +550,174 @@ func TestSQL_Register_CourseNotFound(t *testing.T) {
 	}
 }
 
+func TestSQL_Enroll(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	studentStore := student.NewSQL(db)
+	courseStore := course.NewSQL(db)
+	ctx := context.Background()
+
+	for _, c := range []model.Course{
+		{ID: "10101010", Name: "Internet Engineering"},
+		{ID: "20202020", Name: "Compiler Design"},
+	} {
+		if err := courseStore.Create(ctx, c); err != nil {
+			t.Fatalf("failed to create course: %v", err)
+		}
+	}
+
+	st := model.Student{ID: "12345678", Name: "Parham Alvani", Courses: nil}
+
+	if err := studentStore.Create(ctx, st); err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	e, created, err := studentStore.Enroll(ctx, st.ID, "10101010")
+	if err != nil {
+		t.Fatalf("failed to enroll student: %v", err)
+	}
+
+	if !created || e.Status != model.EnrollmentEnrolled || e.EnrolledAt == nil || e.CourseName != "Internet Engineering" {
+		t.Fatalf("expected a new enrollment, got %t and %+v", created, e)
+	}
+
+	again, created, err := studentStore.Enroll(ctx, st.ID, "10101010")
+	if err != nil {
+		t.Fatalf("failed to enroll student again: %v", err)
+	}
+
+	if created || !again.EnrolledAt.Equal(*e.EnrolledAt) {
+		t.Errorf("expected the existing enrollment, got %t and %+v", created, again)
+	}
+
+	// enrollments which are created before the registration time was kept.
+	err = db.Exec("INSERT INTO `students_courses` (`sql_item_id`, `course_id`) VALUES (?, ?)", st.ID, "20202020").Error
+	if err != nil {
+		t.Fatalf("failed to insert an old enrollment: %v", err)
+	}
+
+	es, err := studentStore.StudentEnrollments(ctx, st.ID)
+	if err != nil {
+		t.Fatalf("failed to list enrollments: %v", err)
+	}
+
+	if len(es) != 2 || es[0].CourseID != "10101010" || es[1].CourseID != "20202020" || es[1].EnrolledAt != nil {
+		t.Errorf("expected both enrollments ordered by course, got %+v", es)
+	}
+
+	dropped, err := studentStore.Drop(ctx, st.ID, "10101010")
+	if err != nil {
+		t.Fatalf("failed to drop the course: %v", err)
+	}
+
+	if dropped.Status != model.EnrollmentDropped || dropped.DroppedAt == nil || !dropped.EnrolledAt.Equal(*e.EnrolledAt) {
+		t.Errorf("expected the dropped enrollment, got %+v", dropped)
+	}
+
+	if _, err := studentStore.Enrollment(ctx, st.ID, "10101010"); !errors.Is(err, student.ErrStudentNotRegistered) {
+		t.Errorf("expected ErrStudentNotRegistered, got %v", err)
+	}
+
+	if _, err := studentStore.Drop(ctx, st.ID, "10101010"); !errors.Is(err, student.ErrStudentNotRegistered) {
+		t.Errorf("expected ErrStudentNotRegistered on dropping again, got %v", err)
+	}
+
+	if _, err := studentStore.StudentEnrollments(ctx, "99999999"); !errors.Is(err, student.ErrStudentNotFound) {
+		t.Errorf("expected ErrStudentNotFound, got %v", err)
+	}
+}
+
+func TestSQL_Get_LegacyCapacity(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	// courses table before the capacities and instructors, the new columns are null on its rows.
+	err := db.Exec("CREATE TABLE `courses` (`id` text, `name` text, PRIMARY KEY (`id`))").Error
+	if err != nil {
+		t.Fatalf("failed to create the legacy courses: %v", err)
+	}
+
+	err = db.Exec("INSERT INTO `courses` (`id`, `name`) VALUES (?, ?)", "10101010", "Internet Engineering").Error
+	if err != nil {
+		t.Fatalf("failed to insert a legacy course: %v", err)
+	}
+
+	courseStore := course.NewSQL(db)
+	studentStore := student.NewSQL(db)
+
+	st := model.Student{ID: "12345678", Name: "Parham Alvani", Courses: nil}
+
+	if err := studentStore.Create(ctx, st); err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	if err := studentStore.Register(ctx, st.ID, "10101010"); err != nil {
+		t.Fatalf("failed to register student: %v", err)
+	}
+
+	got, err := studentStore.Get(ctx, st.ID)
+	if err != nil {
+		t.Fatalf("failed to get student: %v", err)
+	}
+
+	if len(got.Courses) != 1 || got.Courses[0].Capacity != 0 {
+		t.Errorf("expected the legacy course without capacity, got %+v", got.Courses)
+	}
+
+	cs, err := courseStore.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("failed to get courses: %v", err)
+	}
+
+	if len(cs) != 1 || cs[0].Capacity != 0 {
+		t.Errorf("expected the legacy course without capacity, got %+v", cs)
+	}
+}
+
+func TestSQL_Get_LegacyInstructor(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	// courses table before the instructors, the new column is null on its rows.
+	err := db.Exec("CREATE TABLE `courses` (`id` text, `name` text, `capacity` integer NOT NULL DEFAULT 0, " +
+		"PRIMARY KEY (`id`))").Error
+	if err != nil {
+		t.Fatalf("failed to create the legacy courses: %v", err)
+	}
+
+	err = db.Exec("INSERT INTO `courses` (`id`, `name`) VALUES (?, ?)", "10101010", "Internet Engineering").Error
+	if err != nil {
+		t.Fatalf("failed to insert a legacy course: %v", err)
+	}
+
+	_ = course.NewSQL(db)
+	studentStore := student.NewSQL(db)
+
+	st := model.Student{ID: "12345678", Name: "Parham Alvani", Courses: nil}
+
+	if err := studentStore.Create(ctx, st); err != nil {
+		t.Fatalf("failed to create student: %v", err)
+	}
+
+	if err := studentStore.Register(ctx, st.ID, "10101010"); err != nil {
+		t.Fatalf("failed to register student: %v", err)
+	}
+
+	got, err := studentStore.Get(ctx, st.ID)
+	if err != nil {
+		t.Fatalf("failed to get student: %v", err)
+	}
+
+	if len(got.Courses) != 1 || got.Courses[0].Instructor != "" {
+		t.Errorf("expected the legacy course without instructor, got %+v", got.Courses)
+	}
+}
+
 func TestSQL_Register_MultipleCourses(t *testing.T) {
 	t.Parallel()
 
@@ -522,3 +798,85 @@ func TestSQL_Get_WrongID(t *testing.T) {
 		t.Errorf("expected ErrStudentNotFound, got %v", err)
 	}
 }
+
+func TestSQL_CreateAll_Rollback(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	store := student.NewSQL(db)
+	ctx := context.Background()
+
+	students := []model.Student{
+		{ID: "11111111", Name: "Student One", Courses: nil},
+		{ID: "22222222", Name: "Student Two", Courses: nil},
+		{ID: "11111111", Name: "Student Three", Courses: nil},
+	}
+
+	err := store.CreateAll(ctx, students)
+
+	var be storepkg.BatchError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected batch error, got %v", err)
+	}
+
+	if be.Index != 2 {
+		t.Errorf("expected failure on item 2, got %d", be.Index)
+	}
+
+	got, err := store.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("failed to get all students: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("expected rollback of all students, got %d", len(got))
+	}
+}
+
+func TestSQL_Roster(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+	studentStore := student.NewSQL(db)
+	courseStore := course.NewSQL(db)
+	ctx := context.Background()
+
+	c := model.Course{ID: "10101010", Name: "Internet Engineering"}
+
+	if err := courseStore.Create(ctx, c); err != nil {
+		t.Fatalf("failed to create course: %v", err)
+	}
+
+	students := []model.Student{
+		{ID: "11111111", Name: "Student One", Courses: nil},
+		{ID: "22222222", Name: "Student Two", Courses: nil},
+	}
+
+	if err := studentStore.CreateAll(ctx, students); err != nil {
+		t.Fatalf("failed to create students: %v", err)
+	}
+
+	if err := studentStore.Register(ctx, students[1].ID, c.ID); err != nil {
+		t.Fatalf("failed to register student: %v", err)
+	}
+
+	var got []model.Student
+
+	err := studentStore.Roster(ctx, c.ID, func(st model.Student) error {
+		got = append(got, st)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read roster: %v", err)
+	}
+
+	if len(got) != 1 || got[0].ID != students[1].ID || got[0].Name != students[1].Name {
+		t.Errorf("expected roster with %+v, got %+v", students[1], got)
+	}
+
+	err = studentStore.Roster(ctx, "99999999", func(model.Student) error { return nil })
+	if !errors.Is(err, course.ErrCourseNotFound) {
+		t.Errorf("expected ErrCourseNotFound, got %v", err)
+	}
+}
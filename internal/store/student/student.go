@@ -10,11 +10,33 @@ import (
 var (
 	ErrStudentAlreadyExists = errors.New("student already exists")
 	ErrStudentNotFound      = errors.New("student does not exist")
+	ErrStudentNotRegistered = errors.New("student is not registered in the course")
+	ErrNationalIDTaken      = errors.New("national id belongs to another student")
 )
 
 type Student interface {
 	GetAll(ctx context.Context) ([]model.Student, error)
 	Create(ctx context.Context, student model.Student) error
+	// CreateAll creates all the given students in a single transaction,
+	// store.BatchError reports the student which caused the rollback.
+	CreateAll(ctx context.Context, students []model.Student) error
 	Get(ctx context.Context, id string) (model.Student, error)
 	Register(ctx context.Context, sid string, cid string) error
+	// Enroll registers the student into the course like Register and returns the enrollment,
+	// it reports whether the enrollment is created or the student was already registered.
+	Enroll(ctx context.Context, sid string, cid string) (model.Enrollment, bool, error)
+	Unregister(ctx context.Context, sid string, cid string) error
+	// Drop unregisters the student from the course like Unregister and returns the dropped enrollment,
+	// the enrollment is read and deleted in a single transaction.
+	Drop(ctx context.Context, sid string, cid string) (model.Enrollment, error)
+	// Roster calls fn for each student of the given course without loading all of them into memory.
+	Roster(ctx context.Context, cid string, fn func(model.Student) error) error
+	// Enrolled returns the number of students which are registered in the given course.
+	Enrolled(ctx context.Context, cid string) (int, error)
+	// Enrollment returns the enrollment of the student in the course or ErrStudentNotRegistered.
+	Enrollment(ctx context.Context, sid string, cid string) (model.Enrollment, error)
+	// StudentEnrollments returns the enrollments of the student, ordered by course.
+	StudentEnrollments(ctx context.Context, sid string) ([]model.Enrollment, error)
+	// Enrollments calls fn for each registration of students into courses, ordered by course.
+	Enrollments(ctx context.Context, fn func(model.Enrollment) error) error
 }
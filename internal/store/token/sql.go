@@ -0,0 +1,60 @@
+package token
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SQLItem struct {
+	ID        string    `gorm:"primaryKey"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+func (SQLItem) TableName() string {
+	return "revoked_tokens"
+}
+
+type SQL struct {
+	db *gorm.DB
+}
+
+func NewSQL(db *gorm.DB) Token {
+	err := db.AutoMigrate(new(SQLItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return SQL{
+		db: db,
+	}
+}
+
+func (sql SQL) Revoke(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	// a revoked token can be revoked again, but it doesn't insert a row.
+	item := SQLItem{ID: id, ExpiresAt: expiresAt.UTC()}
+
+	res := sql.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&item) // nolint: exhaustruct
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	_, err := gorm.G[SQLItem](sql.db).Where("expires_at < ?", time.Now().UTC()).Delete(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (sql SQL) Revoked(ctx context.Context, id string) (bool, error) {
+	n, err := gorm.G[SQLItem](sql.db).Where("id = ?", id).Count(ctx, "id")
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -0,0 +1,59 @@
+package token_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/store/token"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSQL_Revoke(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "students.db")), &gorm.Config{ //nolint:exhaustruct
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	store := token.NewSQL(db)
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+
+	var (
+		wg      sync.WaitGroup
+		revoked atomic.Int64
+	)
+
+	// only one of the concurrent revocations revokes the token.
+	for range 10 {
+		wg.Go(func() {
+			ok, err := store.Revoke(ctx, "refresh", expires)
+			if err != nil {
+				t.Errorf("failed to revoke token: %v", err)
+			}
+
+			if ok {
+				revoked.Add(1)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if revoked.Load() != 1 {
+		t.Errorf("expected a single revocation, got %d", revoked.Load())
+	}
+
+	if ok, err := store.Revoked(ctx, "refresh"); err != nil || !ok {
+		t.Errorf("expected the token to be revoked, got %t and %v", ok, err)
+	}
+}
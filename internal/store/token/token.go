@@ -0,0 +1,14 @@
+package token
+
+import (
+	"context"
+	"time"
+)
+
+// Token keeps the ids of the revoked tokens until they expire.
+type Token interface {
+	// Revoke revokes the token with the given id, it also forgets the revoked tokens which are expired.
+	// It reports whether this call revoked the token, so only one of the concurrent calls revokes it.
+	Revoke(ctx context.Context, id string, expiresAt time.Time) (bool, error)
+	Revoked(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/model"
+	"gorm.io/gorm"
+)
+
+type SQLItem struct {
+	Username     string `gorm:"primaryKey"`
+	PasswordHash []byte
+	Role         string
+	StudentID    string
+	CreatedAt    time.Time
+}
+
+func (SQLItem) TableName() string {
+	return "users"
+}
+
+type SQL struct {
+	db *gorm.DB
+}
+
+func NewSQL(db *gorm.DB) User {
+	err := db.AutoMigrate(new(SQLItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return SQL{
+		db: db,
+	}
+}
+
+func (sql SQL) Create(ctx context.Context, u model.User) error {
+	item := SQLItem{
+		Username:     u.Username,
+		PasswordHash: u.PasswordHash,
+		Role:         string(u.Role),
+		StudentID:    u.StudentID,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	err := gorm.G[SQLItem](sql.db).Create(ctx, &item)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrUsernameTaken
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (sql SQL) Get(ctx context.Context, username string) (model.User, error) {
+	item, err := gorm.G[SQLItem](sql.db).Where("username = ?", username).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.User{}, ErrUserNotFound
+		}
+
+		return model.User{}, err
+	}
+
+	return model.User{
+		Username:     item.Username,
+		PasswordHash: item.PasswordHash,
+		Role:         model.Role(item.Role),
+		StudentID:    item.StudentID,
+		CreatedAt:    item.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+var (
+	ErrUserNotFound  = errors.New("user does not exist")
+	ErrUsernameTaken = errors.New("username is already taken")
+)
+
+// User keeps the users which can log in.
+type User interface {
+	Create(ctx context.Context, u model.User) error
+	Get(ctx context.Context, username string) (model.User, error)
+}
@@ -0,0 +1,315 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SQLItem struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	URL       string
+	Secret    string
+	Events    string
+	Active    bool
+	CreatedAt time.Time
+}
+
+func (SQLItem) TableName() string {
+	return "webhooks"
+}
+
+type DeliveryItem struct {
+	ID             uint64 `gorm:"primaryKey;autoIncrement"`
+	WebhookID      uint64 `gorm:"uniqueIndex:idx_webhook_deliveries_event"`
+	EventID        uint64 `gorm:"uniqueIndex:idx_webhook_deliveries_event"`
+	EventType      string
+	Body           []byte
+	Status         string `gorm:"index"`
+	Attempts       int
+	LastError      string
+	ResponseStatus int
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func (DeliveryItem) TableName() string {
+	return "webhook_deliveries"
+}
+
+type SQL struct {
+	db *gorm.DB
+}
+
+func NewSQL(db *gorm.DB) Webhook {
+	err := db.AutoMigrate(new(SQLItem), new(DeliveryItem))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return SQL{
+		db: db,
+	}
+}
+
+func toModel(item SQLItem) model.Webhook {
+	events := []string{}
+	if item.Events != "" {
+		events = strings.Split(item.Events, ",")
+	}
+
+	return model.Webhook{
+		ID:        item.ID,
+		URL:       item.URL,
+		Secret:    item.Secret,
+		Events:    events,
+		Active:    item.Active,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+func toDelivery(item DeliveryItem) model.Delivery {
+	return model.Delivery{
+		ID:             item.ID,
+		WebhookID:      item.WebhookID,
+		EventID:        item.EventID,
+		EventType:      item.EventType,
+		Body:           item.Body,
+		Status:         model.DeliveryStatus(item.Status),
+		Attempts:       item.Attempts,
+		LastError:      item.LastError,
+		ResponseStatus: item.ResponseStatus,
+		NextAttemptAt:  item.NextAttemptAt,
+		CreatedAt:      item.CreatedAt,
+		DeliveredAt:    item.DeliveredAt,
+	}
+}
+
+func (sql SQL) Create(ctx context.Context, w model.Webhook) (model.Webhook, error) {
+	item := SQLItem{
+		ID:        0,
+		URL:       w.URL,
+		Secret:    w.Secret,
+		Events:    strings.Join(w.Events, ","),
+		Active:    w.Active,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := gorm.G[SQLItem](sql.db).Create(ctx, &item)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+
+	return toModel(item), nil
+}
+
+func (sql SQL) GetAll(ctx context.Context) ([]model.Webhook, error) {
+	items, err := gorm.G[SQLItem](sql.db).Order("id").Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]model.Webhook, 0, len(items))
+	for _, item := range items {
+		webhooks = append(webhooks, toModel(item))
+	}
+
+	return webhooks, nil
+}
+
+func (sql SQL) Get(ctx context.Context, id uint64) (model.Webhook, error) {
+	item, err := gorm.G[SQLItem](sql.db).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Webhook{}, ErrWebhookNotFound
+		}
+
+		return model.Webhook{}, err
+	}
+
+	return toModel(item), nil
+}
+
+func (sql SQL) Update(ctx context.Context, w model.Webhook) error {
+	rows, err := gorm.G[SQLItem](sql.db).Where("id = ?", w.ID).Set(clause.Assignments(map[string]any{
+		"url":    w.URL,
+		"secret": w.Secret,
+		"events": strings.Join(w.Events, ","),
+		"active": w.Active,
+	})).Update(ctx)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (sql SQL) Delete(ctx context.Context, id uint64) error {
+	return sql.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := gorm.G[DeliveryItem](tx).Where("webhook_id = ?", id).Delete(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err := gorm.G[SQLItem](tx).Where("id = ?", id).Delete(ctx)
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return ErrWebhookNotFound
+		}
+
+		return nil
+	})
+}
+
+func (sql SQL) Enqueue(ctx context.Context, e event.Event) error {
+	webhooks, err := gorm.G[SQLItem](sql.db).Where("active = ?", true).Find(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	var items []DeliveryItem
+
+	for _, w := range webhooks {
+		if w.Events != "" && !slices.Contains(strings.Split(w.Events, ","), string(e.Type)) {
+			continue
+		}
+
+		items = append(items, DeliveryItem{
+			ID:             0,
+			WebhookID:      w.ID,
+			EventID:        e.ID,
+			EventType:      string(e.Type),
+			Body:           body,
+			Status:         string(model.DeliveryPending),
+			Attempts:       0,
+			LastError:      "",
+			ResponseStatus: 0,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			DeliveredAt:    nil,
+		})
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return gorm.G[DeliveryItem](sql.db, clause.OnConflict{DoNothing: true}).CreateInBatches(ctx, &items, len(items)) // nolint: exhaustruct
+}
+
+func (sql SQL) Due(ctx context.Context, limit int) ([]model.Delivery, error) {
+	items, err := gorm.G[DeliveryItem](sql.db).
+		Where("status = ? AND next_attempt_at <= ?", model.DeliveryPending, time.Now().UTC()).
+		Where("webhook_id IN (SELECT id FROM webhooks WHERE active = ?)", true).
+		Order("id").Limit(limit).Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]model.Delivery, 0, len(items))
+	for _, item := range items {
+		deliveries = append(deliveries, toDelivery(item))
+	}
+
+	return deliveries, nil
+}
+
+func (sql SQL) Deliveries(
+	ctx context.Context, webhookID uint64, status model.DeliveryStatus, limit int,
+) ([]model.Delivery, error) {
+	q := gorm.G[DeliveryItem](sql.db).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	items, err := q.Order("id DESC").Limit(limit).Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]model.Delivery, 0, len(items))
+	for _, item := range items {
+		deliveries = append(deliveries, toDelivery(item))
+	}
+
+	return deliveries, nil
+}
+
+func (sql SQL) MarkDelivered(ctx context.Context, id uint64, code int) error {
+	_, err := gorm.G[DeliveryItem](sql.db).Where("id = ?", id).Set(clause.Assignments(map[string]any{
+		"status":          model.DeliveryDelivered,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      "",
+		"response_status": code,
+		"delivered_at":    time.Now().UTC(),
+	})).Update(ctx)
+
+	return err
+}
+
+func (sql SQL) MarkFailed(ctx context.Context, id uint64, code int, cause error, retryAt time.Time) error {
+	_, err := gorm.G[DeliveryItem](sql.db).Where("id = ?", id).Set(clause.Assignments(map[string]any{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      cause.Error(),
+		"response_status": code,
+		"next_attempt_at": retryAt.UTC(),
+	})).Update(ctx)
+
+	return err
+}
+
+func (sql SQL) MarkDead(ctx context.Context, id uint64, code int, cause error) error {
+	_, err := gorm.G[DeliveryItem](sql.db).Where("id = ?", id).Set(clause.Assignments(map[string]any{
+		"status":          model.DeliveryDead,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      cause.Error(),
+		"response_status": code,
+	})).Update(ctx)
+
+	return err
+}
+
+func (sql SQL) Replay(ctx context.Context, webhookID uint64, id uint64) error {
+	rows, err := gorm.G[DeliveryItem](sql.db).Where("id = ? AND webhook_id = ?", id, webhookID).
+		Set(clause.Assignments(map[string]any{
+			"status":          model.DeliveryPending,
+			"attempts":        0,
+			"last_error":      "",
+			"response_status": 0,
+			"next_attempt_at": time.Now().UTC(),
+			"delivered_at":    nil,
+		})).Update(ctx)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrDeliveryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/model"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook does not exist")
+	ErrDeliveryNotFound = errors.New("delivery does not exist")
+)
+
+// Webhook keeps the webhook subscriptions and their deliveries.
+type Webhook interface {
+	// Create creates the webhook and returns it with its assigned id.
+	Create(ctx context.Context, w model.Webhook) (model.Webhook, error)
+	GetAll(ctx context.Context) ([]model.Webhook, error)
+	Get(ctx context.Context, id uint64) (model.Webhook, error)
+	Update(ctx context.Context, w model.Webhook) error
+	// Delete deletes the webhook with all of its deliveries.
+	Delete(ctx context.Context, id uint64) error
+
+	// Enqueue creates a pending delivery of the event for each active webhook which is
+	// subscribed to its type, enqueueing an event more than once has no effect.
+	Enqueue(ctx context.Context, e event.Event) error
+	// Due returns at most limit pending deliveries of the active webhooks which are due, ordered by their id.
+	Due(ctx context.Context, limit int) ([]model.Delivery, error)
+	// Deliveries returns the deliveries of the webhook, newest first, an empty status returns all of them.
+	Deliveries(ctx context.Context, webhookID uint64, status model.DeliveryStatus, limit int) ([]model.Delivery, error)
+	MarkDelivered(ctx context.Context, id uint64, code int) error
+	// MarkFailed records a failed attempt, the delivery is retried after the given time.
+	MarkFailed(ctx context.Context, id uint64, code int, cause error, retryAt time.Time) error
+	// MarkDead records the last failed attempt and moves the delivery into the dead-letter list.
+	MarkDead(ctx context.Context, id uint64, code int, cause error) error
+	// Replay makes the delivery pending again with no attempts, regardless of its status.
+	Replay(ctx context.Context, webhookID uint64, id uint64) error
+}
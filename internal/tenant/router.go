@@ -0,0 +1,117 @@
+package tenant
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/1995parham-teaching/students/internal/i18n"
+	"github.com/1995parham-teaching/students/internal/problem"
+)
+
+// Header names the tenant of a request, it takes precedence over the host.
+const Header = "X-Tenant"
+
+// Router routes each request to the handler of its tenant. The tenant is resolved from the
+// Header, then from the configured hosts and then from the subdomain of the Domain,
+// e.g. aut.students.example.com is served by the aut tenant when the domain is students.example.com.
+type Router struct {
+	Handlers map[string]http.Handler
+	Hosts    map[string]string
+	Domain   string
+	Default  string
+}
+
+func NewRouter(c Config, handlers map[string]http.Handler) Router {
+	hosts := make(map[string]string)
+
+	for _, t := range c.Tenants {
+		for _, h := range t.Hosts {
+			hosts[strings.ToLower(h)] = t.ID
+		}
+	}
+
+	return Router{
+		Handlers: handlers,
+		Hosts:    hosts,
+		Domain:   strings.ToLower(c.Domain),
+		Default:  c.Default,
+	}
+}
+
+// fromHost resolves the tenant from the request host without its port, the subdomains of the domain
+// always name a tenant even when it doesn't exist.
+func (r Router) fromHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	host = strings.ToLower(host)
+
+	if id, ok := r.Hosts[host]; ok {
+		return id
+	}
+
+	if r.Domain == "" {
+		return ""
+	}
+
+	// the hosts out of the domain (e.g. ip addresses or localhost) and the domain itself don't name a tenant,
+	// the nested subdomains name an invalid tenant.
+	label, ok := strings.CutSuffix(host, "."+r.Domain)
+	if !ok {
+		return ""
+	}
+
+	return label
+}
+
+func (r Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get(Header)
+	host := r.fromHost(req.Host)
+
+	id := header
+	if id == "" {
+		id = host
+	}
+
+	// the header can't move a request of a tenant host into another tenant.
+	if header != "" && host != "" && header != host {
+		fail(w, req, problem.New(http.StatusBadRequest, problem.CodeTenantMismatch, "tenant header does not match the host"))
+
+		return
+	}
+
+	if id == "" {
+		id = r.Default
+	}
+
+	if id == "" {
+		fail(w, req, problem.New(http.StatusBadRequest, problem.CodeTenantRequired, "tenant is required"))
+
+		return
+	}
+
+	h, ok := r.Handlers[id]
+	if !ok {
+		fail(w, req, problem.New(http.StatusNotFound, problem.CodeUnknownTenant, "tenant does not exist"))
+
+		return
+	}
+
+	h.ServeHTTP(w, req.WithContext(WithID(req.Context(), id)))
+}
+
+// fail writes the problem in the same format as the echo errors, the request id is
+// the one which is given by the client, if any, and it is in the language of the request.
+func fail(w http.ResponseWriter, req *http.Request, p *problem.Problem) {
+	p.Instance = req.URL.Path
+	p.RequestID = req.Header.Get("X-Request-Id")
+	p.Localize(i18n.Parse(req.Header.Get("Accept-Language")))
+
+	err := problem.Write(w, p)
+	if err != nil {
+		log.Println(err)
+	}
+}
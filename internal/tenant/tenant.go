@@ -0,0 +1,145 @@
+// Package tenant hosts several universities (or faculties) in a single deployment. Each tenant
+// has its own SQLite database and settings, so the stores of one tenant never see the data of another.
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidID      = errors.New("tenant id must be 1 to 32 lowercase letters, digits or dashes")
+	ErrDuplicateID    = errors.New("tenant id is duplicated")
+	ErrDuplicateHost  = errors.New("tenant host is duplicated")
+	ErrSharedDatabase = errors.New("tenant database is shared with another tenant")
+	ErrUnknownDefault = errors.New("default tenant does not exist")
+	ErrUnknownTenant  = errors.New("tenant does not exist")
+	ErrNoTenant       = errors.New("there is no tenant")
+)
+
+// id matches the valid tenant ids, they are used as subdomains and file names.
+var id = regexp.MustCompile(`^[a-z0-9-]{1,32}$`) // nolint: gochecknoglobals
+
+// Tenant is a university with its own database and settings, empty settings are
+// replaced with the server defaults.
+type Tenant struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Database string   `json:"database"`
+	Hosts    []string `json:"hosts"`
+
+	Timezone  string   `json:"timezone"`
+	TermStart string   `json:"term_start"`
+	TermEnd   string   `json:"term_end"`
+	Holidays  []string `json:"holidays"`
+}
+
+// Config is the list of tenants, requests which don't name a tenant are served by the Default tenant
+// and they are rejected when there is no default. The subdomains of the Domain name the tenants.
+type Config struct {
+	Default string   `json:"default"`
+	Domain  string   `json:"domain"`
+	Tenants []Tenant `json:"tenants"`
+}
+
+// Load reads the tenants configuration from a json file.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot read tenants %w", err)
+	}
+
+	var c Config
+
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse tenants %w", err)
+	}
+
+	return c, c.Validate()
+}
+
+func (c Config) Validate() error {
+	if len(c.Tenants) == 0 {
+		return ErrNoTenant
+	}
+
+	ids := make(map[string]struct{})
+	hosts := make(map[string]struct{})
+	databases := make(map[string]struct{})
+
+	for _, t := range c.Tenants {
+		if !id.MatchString(t.ID) {
+			return fmt.Errorf("%w: %q", ErrInvalidID, t.ID)
+		}
+
+		if _, ok := ids[t.ID]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateID, t.ID)
+		}
+
+		ids[t.ID] = struct{}{}
+
+		database := t.DatabasePath()
+
+		if _, ok := databases[database]; ok {
+			return fmt.Errorf("%w: %s", ErrSharedDatabase, database)
+		}
+
+		databases[database] = struct{}{}
+
+		for _, h := range t.Hosts {
+			h = strings.ToLower(h)
+
+			if _, ok := hosts[h]; ok {
+				return fmt.Errorf("%w: %s", ErrDuplicateHost, h)
+			}
+
+			hosts[h] = struct{}{}
+		}
+	}
+
+	if _, ok := ids[c.Default]; c.Default != "" && !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownDefault, c.Default)
+	}
+
+	return nil
+}
+
+// Get returns the tenant with the given id.
+func (c Config) Get(id string) (Tenant, error) {
+	for _, t := range c.Tenants {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+
+	return Tenant{}, fmt.Errorf("%w: %s", ErrUnknownTenant, id)
+}
+
+// DatabasePath returns the database of the tenant, tenants without database use their id as the database name.
+func (t Tenant) DatabasePath() string {
+	if t.Database == "" {
+		return t.ID + ".db"
+	}
+
+	return t.Database
+}
+
+type contextKey struct{}
+
+// WithID returns a context which carries the tenant id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// ID returns the tenant id of the request context, it is empty outside the tenant handlers.
+func ID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+
+	return id
+}
@@ -0,0 +1,153 @@
+package tenant_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/1995parham-teaching/students/internal/tenant"
+)
+
+func config() tenant.Config {
+	return tenant.Config{
+		Default: "aut",
+		Domain:  "students.example.com",
+		Tenants: []tenant.Tenant{
+			{ID: "aut", Name: "Amirkabir University of Technology", Database: "aut.db", Hosts: []string{"students.aut.ac.ir"}},
+			{ID: "sharif", Name: "Sharif University of Technology", Database: "", Hosts: nil},
+		},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		change func(c *tenant.Config)
+		err    error
+	}{
+		{"valid", func(_ *tenant.Config) {}, nil},
+		{"no tenant", func(c *tenant.Config) { c.Tenants = nil }, tenant.ErrNoTenant},
+		{"invalid id", func(c *tenant.Config) { c.Tenants[1].ID = "Sharif" }, tenant.ErrInvalidID},
+		{"duplicate id", func(c *tenant.Config) { c.Tenants[1].ID = "aut" }, tenant.ErrDuplicateID},
+		{"duplicate host", func(c *tenant.Config) {
+			c.Tenants[1].Hosts = []string{"Students.AUT.ac.ir"}
+		}, tenant.ErrDuplicateHost},
+		{"shared database", func(c *tenant.Config) { c.Tenants[1].Database = "aut.db" }, tenant.ErrSharedDatabase},
+		{"default database", func(c *tenant.Config) { c.Tenants[0].Database = "sharif.db" }, tenant.ErrSharedDatabase},
+		{"unknown default", func(c *tenant.Config) { c.Default = "ut" }, tenant.ErrUnknownDefault},
+		{"no default", func(c *tenant.Config) { c.Default = "" }, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := config()
+			tc.change(&c)
+
+			err := c.Validate()
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Get(t *testing.T) {
+	t.Parallel()
+
+	c := config()
+
+	aut, err := c.Get("aut")
+	if err != nil || aut.DatabasePath() != "aut.db" {
+		t.Errorf("expected aut with aut.db, got %+v: %v", aut, err)
+	}
+
+	sharif, err := c.Get("sharif")
+	if err != nil || sharif.DatabasePath() != "sharif.db" {
+		t.Errorf("expected sharif with its id as the database, got %+v: %v", sharif, err)
+	}
+
+	if _, err := c.Get("ut"); !errors.Is(err, tenant.ErrUnknownTenant) {
+		t.Errorf("expected %v, got %v", tenant.ErrUnknownTenant, err)
+	}
+}
+
+// whoami responds with the tenant of the request context.
+func whoami(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(tenant.ID(r.Context())))
+}
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	handlers := map[string]http.Handler{
+		"aut":    http.HandlerFunc(whoami),
+		"sharif": http.HandlerFunc(whoami),
+	}
+
+	cases := []struct {
+		name   string
+		host   string
+		header string
+		code   int
+		tenant string
+	}{
+		{"default", "127.0.0.1:1373", "", http.StatusOK, "aut"},
+		{"header", "127.0.0.1:1373", "sharif", http.StatusOK, "sharif"},
+		{"host", "students.aut.ac.ir", "", http.StatusOK, "aut"},
+		{"subdomain", "sharif.students.example.com:1373", "", http.StatusOK, "sharif"},
+		{"unknown subdomain", "ut.students.example.com", "", http.StatusNotFound, ""},
+		{"domain", "students.example.com", "", http.StatusOK, "aut"},
+		{"other domain", "sharif.students.example.org", "", http.StatusOK, "aut"},
+		{"nested subdomain", "api.sharif.students.example.com", "", http.StatusNotFound, ""},
+		{"matching header", "students.aut.ac.ir", "aut", http.StatusOK, "aut"},
+		{"mismatching header", "students.aut.ac.ir", "sharif", http.StatusBadRequest, ""},
+		{"unknown header", "localhost", "ut", http.StatusNotFound, ""},
+	}
+
+	r := tenant.NewRouter(config(), handlers)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/students", nil)
+			req.Host = tc.host
+
+			if tc.header != "" {
+				req.Header.Set(tenant.Header, tc.header)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tc.code {
+				t.Fatalf("expected status %d, got %d", tc.code, w.Code)
+			}
+
+			if tc.code == http.StatusOK && w.Body.String() != tc.tenant {
+				t.Errorf("expected tenant %s, got %s", tc.tenant, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_NoDefault(t *testing.T) {
+	t.Parallel()
+
+	c := config()
+	c.Default = ""
+
+	r := tenant.NewRouter(c, map[string]http.Handler{"aut": http.HandlerFunc(whoami)})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/students", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without tenant, got %d", http.StatusBadRequest, w.Code)
+	}
+}
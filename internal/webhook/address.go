@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var ErrAddressNotAllowed = errors.New("webhook address is not public")
+
+// Allowed reports whether the webhooks can be sent to the address. The loopback, link-local, private
+// and unspecified addresses are not allowed, so the webhooks cannot reach the internal services.
+func Allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() && !addr.IsPrivate() && !addr.IsUnspecified()
+}
+
+// control rejects the connections to the addresses which are not allowed, it runs after the name
+// resolution so the names which are resolved (or rebound) into them are rejected too.
+func control(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil || !Allowed(ap.Addr()) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, address)
+	}
+
+	return nil
+}
+
+// NewClient returns the client of the webhooks, it only connects to the allowed addresses
+// and doesn't use the proxy of the environment because the proxy would be dialed instead of the webhook.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{ // nolint: exhaustruct
+		Timeout: timeout,
+		Control: control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() // nolint: forcetypeassert
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{ // nolint: exhaustruct
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/dispatcher"
+	"github.com/1995parham-teaching/students/internal/model"
+	whstore "github.com/1995parham-teaching/students/internal/store/webhook"
+)
+
+const (
+	DefaultInterval    = time.Second
+	DefaultBatch       = 100
+	DefaultMaxAttempts = 8
+	DefaultTimeout     = 10 * time.Second
+)
+
+var ErrStatus = errors.New("webhook responded with non-2xx status")
+
+// Sender sends the due deliveries to their webhooks.
+type Sender struct {
+	Store       whstore.Webhook
+	Client      *http.Client
+	Interval    time.Duration
+	Batch       int
+	MaxAttempts int
+	Backoff     func(attempts int) time.Duration
+}
+
+func NewSender(store whstore.Webhook) Sender {
+	return Sender{
+		Store:       store,
+		Client:      NewClient(DefaultTimeout),
+		Interval:    DefaultInterval,
+		Batch:       DefaultBatch,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     dispatcher.Backoff,
+	}
+}
+
+// Run polls the deliveries until the context is canceled.
+func (s Sender) Run(ctx context.Context) {
+	t := time.NewTicker(s.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			err := s.Send(ctx)
+			if err != nil {
+				log.Printf("webhook delivery failed %s", err)
+			}
+		}
+	}
+}
+
+// Send sends a batch of the due deliveries and records their result.
+func (s Sender) Send(ctx context.Context) error {
+	deliveries, err := s.Store.Due(ctx, s.Batch)
+	if err != nil {
+		return err
+	}
+
+	webhooks := make(map[uint64]model.Webhook)
+
+	for _, d := range deliveries {
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			w, err = s.Store.Get(ctx, d.WebhookID)
+			if err != nil {
+				// the webhook is deleted with its deliveries in the meantime.
+				if errors.Is(err, whstore.ErrWebhookNotFound) {
+					continue
+				}
+
+				return err
+			}
+
+			webhooks[d.WebhookID] = w
+		}
+
+		code, err := s.post(ctx, w, d)
+
+		switch {
+		case err == nil:
+			err = s.Store.MarkDelivered(ctx, d.ID, code)
+		case d.Attempts+1 >= s.MaxAttempts:
+			err = s.Store.MarkDead(ctx, d.ID, code, err)
+		default:
+			err = s.Store.MarkFailed(ctx, d.ID, code, err, time.Now().Add(s.Backoff(d.Attempts)))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// post sends the delivery and returns the response status code, which is zero
+// when there is no response.
+func (s Sender) post(ctx context.Context, w model.Webhook, d model.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(d.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(w.Secret, timestamp, d.Body))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%w: %d", ErrStatus, resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
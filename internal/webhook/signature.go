@@ -0,0 +1,51 @@
+// Package webhook delivers the domain events to the webhook subscriptions. Each request is
+// signed with HMAC-SHA256 of "<timestamp>.<body>" using the webhook secret, failed deliveries are
+// retried with exponential backoff and after MaxAttempts they are moved into the dead-letter list.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderSignature = "X-Students-Signature"
+	HeaderTimestamp = "X-Students-Timestamp"
+	HeaderEvent     = "X-Students-Event"
+	HeaderDelivery  = "X-Students-Delivery"
+
+	signaturePrefix = "sha256="
+	secretLen       = 32
+)
+
+// Sign returns the signature of the body which is sent at the given unix timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature in constant time, receivers should also reject old timestamps
+// to prevent replay attacks.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// NewSecret generates a random secret for the webhooks which are created without one.
+func NewSecret() string {
+	b := make([]byte, secretLen)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	whstore "github.com/1995parham-teaching/students/internal/store/webhook"
+)
+
+// Sink is the outbox sink of the webhooks, it only creates the deliveries and
+// the Sender sends them, so a slow webhook doesn't block the other sinks.
+type Sink struct {
+	Store whstore.Webhook
+}
+
+func (s Sink) Deliver(ctx context.Context, e event.Event) error {
+	return s.Store.Enqueue(ctx, e)
+}
@@ -0,0 +1,256 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1995parham-teaching/students/internal/event"
+	"github.com/1995parham-teaching/students/internal/model"
+	whstore "github.com/1995parham-teaching/students/internal/store/webhook"
+	"github.com/1995parham-teaching/students/internal/webhook"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+// receiver verifies the signature of the deliveries and fails the first given number of them.
+type receiver struct {
+	failures atomic.Int64
+	received atomic.Int64
+	invalid  atomic.Int64
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	timestamp, _ := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	if !webhook.Verify(secret, timestamp, body, req.Header.Get(webhook.HeaderSignature)) {
+		r.invalid.Add(1)
+	}
+
+	if r.failures.Add(-1) >= 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	r.received.Add(1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setup(t *testing.T, failures int64, events []string) (webhook.Sender, *receiver, model.Webhook) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{ //nolint:exhaustruct
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+
+	r := new(receiver)
+	r.failures.Store(failures)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	store := whstore.NewSQL(db)
+
+	w, err := store.Create(context.Background(), model.Webhook{
+		ID:        0,
+		URL:       srv.URL,
+		Secret:    secret,
+		Events:    events,
+		Active:    true,
+		CreatedAt: time.Time{},
+	})
+	if err != nil {
+		t.Fatalf("failed to create webhook: %v", err)
+	}
+
+	s := webhook.NewSender(store)
+	// the test server listens on the loopback which is not allowed by the sender client.
+	s.Client = srv.Client()
+	s.Backoff = func(int) time.Duration { return 0 }
+	s.MaxAttempts = 3
+
+	return s, r, w
+}
+
+func enqueue(t *testing.T, s webhook.Sender, id uint64, typ event.Type) {
+	t.Helper()
+
+	e, err := event.New(typ, event.Registration{StudentID: "00000001", CourseID: "00000001"})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	e.ID = id
+
+	err = webhook.Sink{Store: s.Store}.Deliver(context.Background(), e)
+	if err != nil {
+		t.Fatalf("failed to enqueue event: %v", err)
+	}
+}
+
+func send(t *testing.T, s webhook.Sender, times int) {
+	t.Helper()
+
+	for range times {
+		err := s.Send(context.Background())
+		if err != nil {
+			t.Fatalf("send failed: %v", err)
+		}
+	}
+}
+
+func TestSender_Retry(t *testing.T) {
+	t.Parallel()
+
+	s, r, w := setup(t, 2, []string{string(event.StudentRegistered)})
+
+	enqueue(t, s, 1, event.StudentRegistered)
+	// filtered out by the event types of the webhook.
+	enqueue(t, s, 2, event.StudentCreated)
+	// enqueued twice by the at least once outbox.
+	enqueue(t, s, 1, event.StudentRegistered)
+
+	send(t, s, 3)
+
+	if r.received.Load() != 1 {
+		t.Fatalf("expected 1 delivery, got %d", r.received.Load())
+	}
+
+	if r.invalid.Load() != 0 {
+		t.Errorf("expected valid signatures, got %d invalid ones", r.invalid.Load())
+	}
+
+	ds, err := s.Store.Deliveries(context.Background(), w.ID, "", 10)
+	if err != nil {
+		t.Fatalf("failed to read deliveries: %v", err)
+	}
+
+	if len(ds) != 1 {
+		t.Fatalf("expected 1 delivery in the log, got %d", len(ds))
+	}
+
+	if ds[0].Status != model.DeliveryDelivered || ds[0].Attempts != 3 {
+		t.Errorf("expected delivered after 3 attempts, got %s after %d", ds[0].Status, ds[0].Attempts)
+	}
+}
+
+func TestSender_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	s, r, w := setup(t, 3, nil)
+	ctx := context.Background()
+
+	enqueue(t, s, 1, event.StudentUnregistered)
+
+	send(t, s, 4)
+
+	dead, err := s.Store.Deliveries(ctx, w.ID, model.DeliveryDead, 10)
+	if err != nil {
+		t.Fatalf("failed to read deliveries: %v", err)
+	}
+
+	if len(dead) != 1 || dead[0].ResponseStatus != http.StatusServiceUnavailable {
+		t.Fatalf("expected 1 dead delivery with 503 status, got %+v", dead)
+	}
+
+	if r.received.Load() != 0 {
+		t.Fatalf("expected no delivery, got %d", r.received.Load())
+	}
+
+	err = s.Store.Replay(ctx, w.ID, dead[0].ID)
+	if err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+
+	send(t, s, 1)
+
+	if r.received.Load() != 1 {
+		t.Errorf("expected replayed delivery, got %d", r.received.Load())
+	}
+}
+
+func TestSender_InternalAddress(t *testing.T) {
+	t.Parallel()
+
+	s, r, w := setup(t, 0, nil)
+	s.Client = webhook.NewClient(time.Second)
+
+	enqueue(t, s, 1, event.StudentRegistered)
+
+	send(t, s, 1)
+
+	ds, err := s.Store.Deliveries(context.Background(), w.ID, "", 10)
+	if err != nil {
+		t.Fatalf("failed to read deliveries: %v", err)
+	}
+
+	if r.received.Load() != 0 || len(ds) != 1 || !strings.Contains(ds[0].LastError, webhook.ErrAddressNotAllowed.Error()) {
+		t.Fatalf("expected the loopback delivery to be rejected, got %+v", ds)
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		addr    string
+		allowed bool
+	}{
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"0.0.0.0", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.addr, func(t *testing.T) {
+			t.Parallel()
+
+			if webhook.Allowed(netip.MustParseAddr(tc.addr)) != tc.allowed {
+				t.Errorf("expected allowed to be %t", tc.allowed)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"id":1}`)
+	signature := webhook.Sign(secret, 1373, body)
+
+	if !webhook.Verify(secret, 1373, body, signature) {
+		t.Error("expected signature to be valid")
+	}
+
+	if webhook.Verify(secret, 1374, body, signature) {
+		t.Error("expected signature of another timestamp to be invalid")
+	}
+
+	if webhook.Verify("another secret", 1373, body, signature) {
+		t.Error("expected signature of another secret to be invalid")
+	}
+}